@@ -1,12 +1,15 @@
 package main
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path"
 	"slices"
 	"strings"
+
+	"github.com/voidwyrm-2/gor/pkg/gor"
 )
 
 /*
@@ -91,46 +94,6 @@ func assertNoError[T any](v T, _ error) T {
 }
 */
 
-func ChangeNestedMapItem(m *map[any]any, dictPath []any, value any, unwrap bool) {
-	var a map[any]any
-	if unwrap {
-		ab := *m
-		a = ab["0"].(map[any]any)
-	} else {
-		a = *m
-	}
-
-	if len(dictPath) > 0 {
-		if len(dictPath) == 1 {
-			a[dictPath[0]] = value
-		} else {
-			inner := a[dictPath[0]]
-			ChangeNestedMapItem(&map[any]any{"0": inner}, dictPath[1:], value, true)
-			a[dictPath[0]] = inner
-		}
-	}
-
-	*m = a
-}
-
-func readFile(fileName string) (string, error) {
-	file, err := os.Open(fileName)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	content := ""
-	for scanner.Scan() {
-		content += scanner.Text() + "\n"
-	}
-	if err := scanner.Err(); err != nil {
-		return "", err
-	}
-	return content, nil
-}
-
 /*
 func writeFile(filename string, data string) error {
 	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE, 0644)
@@ -148,21 +111,46 @@ func writeFile(filename string, data string) error {
 }
 */
 
-func GorREPL(printTokens, printNodes, printVarsEachCycle bool) {
+// reads a line of input, returning false once there's no more
+func readLine(stdin *gor.LineReader) (string, bool) {
+	line, err := stdin.ReadLine(context.Background())
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(line), true
+}
+
+// runs Gor code, ctrl+c stops the script instead of the whole CLI.
+// the interpreter reads from gor.Stdin() like the CLI does, so scripts don't lose input the CLI has already buffered.
+// scripts run from the CLI are trusted, so they also get getEnv and readFile
+func runGor(text, file string, printTokens, printNodes, printVars, printVarsEachCycle bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	in := gor.NewInterpreter()
+	in.AddSystemFuncs()
+	_, err := in.RunGor(ctx, text, file, gor.RunOptions{
+		PrintTokens:        printTokens,
+		PrintNodes:         printNodes,
+		PrintVars:          printVars,
+		PrintVarsEachCycle: printVarsEachCycle,
+	})
+	return err
+}
+
+func GorREPL(stdin *gor.LineReader, printTokens, printNodes, printVarsEachCycle bool) {
 	var codeBuffer []string
-	scanner := bufio.NewScanner(os.Stdin)
 	for {
 		fmt.Print(">>> ")
-		scanner.Scan()
-		input := strings.TrimSpace(scanner.Text())
+		input, ok := readLine(stdin)
 
-		if input == "--exit" || input == "--quit" {
+		if !ok || input == "--exit" || input == "--quit" {
 			return
 		} else {
 			if input != "" {
 				codeBuffer = append(codeBuffer, input)
 			}
-			_, err := RunGor(strings.Join(codeBuffer, "\n"), "<main>", false, printTokens, printNodes, true, printVarsEachCycle)
+			err := runGor(strings.Join(codeBuffer, "\n"), "<main>", printTokens, printNodes, true, printVarsEachCycle)
 			if err != nil {
 				fmt.Println(err.Error())
 				codeBuffer = codeBuffer[:len(codeBuffer)-1]
@@ -232,18 +220,20 @@ func main() {
 		return
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
+	stdin := gor.Stdin()
 	for {
 		fmt.Print("> ")
-		scanner.Scan()
-		command := strings.TrimSpace(scanner.Text())
+		command, ok := readLine(stdin)
+		if !ok {
+			return
+		}
 
 		switch command {
 		case "exit", "quit":
 			return
 		case "repl":
 			fmt.Println("Gor REPL(type '--exit' or '--quit' to end the repl)")
-			GorREPL(printTokens, printNodes, printVarsEachCycle)
+			GorREPL(stdin, printTokens, printNodes, printVarsEachCycle)
 		case "help":
 			fmt.Println(strings.Join([]string{
 				"'help': shows this text",
@@ -276,13 +266,15 @@ func main() {
 					fileName += ".gor"
 				}
 
-				content, err := readFile(fileName)
+				content, err := os.ReadFile(fileName)
 				if err != nil {
 					fmt.Println(err.Error())
 					continue
 				}
 
-				RunGor(content, fileName, false, printTokens, printNodes, printVars, printVarsEachCycle)
+				if err := runGor(string(content), fileName, printTokens, printNodes, printVars, printVarsEachCycle); err != nil {
+					fmt.Println(err.Error())
+				}
 			} else {
 				fmt.Println("unknown command")
 			}
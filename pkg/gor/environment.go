@@ -0,0 +1,77 @@
+package gor
+
+// a scope of variables, which can see the variables of every scope around it
+type Environment struct {
+	vars   map[string]Value
+	parent *Environment
+	// '<-' doesn't reassign variables from outside of the function an environment belongs to
+	isFunction bool
+}
+
+func NewEnvironment(parent *Environment, isFunction bool) *Environment {
+	return &Environment{vars: make(map[string]Value), parent: parent, isFunction: isFunction}
+}
+
+// the variables defined directly in this scope
+func (e *Environment) Vars() map[string]Value {
+	return e.vars
+}
+
+func (e *Environment) Get(name string) (Value, bool) {
+	for scope := e; scope != nil; scope = scope.parent {
+		if val, ok := scope.vars[name]; ok {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+// defines the variable in this scope, even if a scope around it already has a variable with that name
+func (e *Environment) Define(name string, value Value) {
+	e.vars[name] = value
+}
+
+// assigns to the variable if it's visible from within the current function, otherwise it's defined in this scope
+func (e *Environment) Set(name string, value Value) {
+	for scope := e; scope != nil; scope = scope.parent {
+		if _, ok := scope.vars[name]; ok {
+			scope.vars[name] = value
+			return
+		} else if scope.isFunction {
+			break
+		}
+	}
+	e.vars[name] = value
+}
+
+// assigns to the variable in the scopes outside of the current function like R's '<<-', defining it globally if it doesn't exist
+func (e *Environment) SetSuper(name string, value Value) {
+	scope := e
+	for scope.parent != nil && !scope.isFunction {
+		scope = scope.parent
+	}
+	if scope.parent != nil {
+		scope = scope.parent
+	}
+
+	for ; scope.parent != nil; scope = scope.parent {
+		if _, ok := scope.vars[name]; ok {
+			scope.vars[name] = value
+			return
+		}
+	}
+	scope.vars[name] = value
+}
+
+// removes the variable from the nearest scope within the current function which has it, returning whether it existed
+func (e *Environment) Delete(name string) bool {
+	for scope := e; scope != nil; scope = scope.parent {
+		if _, ok := scope.vars[name]; ok {
+			delete(scope.vars, name)
+			return true
+		} else if scope.isFunction {
+			break
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package gor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+)
+
+// an instance of Gor which Go programs can give their own functions and variables to,
+// its global variables are kept between runs
+type Interpreter struct {
+	globals *Environment
+	funcs   map[string]Value
+	// every input builtin reads through the same buffered reader, so input read ahead by one call isn't lost to the next
+	stdin          *LineReader
+	stdout, stderr io.Writer
+	limits         *runLimits
+	policy         *Policy
+	// the file of the code running right now, which functions are given when they're created
+	file string
+}
+
+// creates an interpreter with the builtin functions, using os.Stdin, os.Stdout and os.Stderr and trusting scripts with everything
+func NewInterpreter() *Interpreter {
+	policy := TrustedPolicy()
+	in := &Interpreter{
+		globals: NewEnvironment(nil, false),
+		funcs:   make(map[string]Value),
+		stdin:   Stdin(),
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
+		limits:  &runLimits{ctx: context.Background(), maxDepth: defaultMaxCallDepth},
+		policy:  &policy,
+	}
+	in.addBuiltins()
+	return in
+}
+
+// an interpreter for a module, which gets the functions, streams, limits and policy of the interpreter using it but its own global variables
+func newModuleInterpreter(parent *Interpreter) *Interpreter {
+	return &Interpreter{
+		globals: NewEnvironment(nil, false),
+		funcs:   maps.Clone(parent.funcs),
+		stdin:   parent.stdin,
+		stdout:  parent.stdout,
+		stderr:  parent.stderr,
+		limits:  parent.limits,
+		policy:  parent.policy,
+	}
+}
+
+// makes the file the one code is running from until the returned function is called
+func (in *Interpreter) enterFile(file string) func() {
+	prev := in.file
+	in.file = file
+	return func() {
+		in.file = prev
+	}
+}
+
+// checks that the name can be used as an identifier in Gor code
+func validateName(name string) error {
+	tokens, err := Lex(name)
+	if err != nil || len(tokens) != 1 || !tokens[0].Istype(IDENT) {
+		return fmt.Errorf("'%s' is not a valid Gor identifier", name)
+	}
+	return nil
+}
+
+// makes a Go function callable from Gor, replacing any builtin with the same name.
+// Gor values are converted to the function's parameter types, so it can take Values or plain Go types like int, float64, string, bool and slices of them,
+// and it can return nothing, a value, an error, or a value and an error
+func (in *Interpreter) RegisterFunc(name string, fn any) error {
+	if err := validateName(name); err != nil {
+		return err
+	} else if fn == nil {
+		return fmt.Errorf("cannot register nil as function '%s'", name)
+	} else if err := validateNative(reflect.TypeOf(fn)); err != nil {
+		return fmt.Errorf("cannot register function '%s': %w", name, err)
+	} else if reflect.ValueOf(fn).IsNil() {
+		return fmt.Errorf("cannot register nil as function '%s'", name)
+	}
+
+	in.funcs[name] = &Function{Name: name, Native: fn}
+	return nil
+}
+
+// like RegisterFunc, but the function can only be called if the interpreter's policy allows everything it needs
+func (in *Interpreter) RegisterRestrictedFunc(name string, needs Capability, fn any) error {
+	if err := in.RegisterFunc(name, fn); err != nil {
+		return err
+	}
+	in.funcs[name].(*Function).Needs = needs
+	return nil
+}
+
+// sets a global variable, converting the value with FromGo
+func (in *Interpreter) SetGlobal(name string, value any) error {
+	if err := validateName(name); err != nil {
+		return err
+	} else if _, exists := in.funcs[name]; exists {
+		return fmt.Errorf("cannot set global '%s' as it's the name of a builtin", name)
+	}
+
+	val, err := FromGo(value)
+	if err != nil {
+		return fmt.Errorf("cannot set global '%s': %w", name, err)
+	}
+	if fn, ok := val.(*Function); ok && fn.Name == "" {
+		fn.Name = name
+	}
+
+	in.globals.Define(name, val)
+	return nil
+}
+
+// gets a global variable, ToGo can be used to turn it into a plain Go value
+func (in *Interpreter) GetGlobal(name string) (Value, bool) {
+	val, ok := in.globals.Vars()[name]
+	return val, ok
+}
+
+// sets where input builtins like getStr read from, os.Stdin is shared with gor.Stdin() instead of being buffered again
+func (in *Interpreter) SetStdin(r io.Reader) {
+	if r == os.Stdin {
+		in.stdin = Stdin()
+		return
+	}
+	in.stdin = NewLineReader(r)
+}
+
+// like SetStdin, but reads through a LineReader that can be shared with other interpreters or the program itself
+func (in *Interpreter) SetLineReader(lr *LineReader) {
+	in.stdin = lr
+}
+
+// sets where puts and the debug output of the interpreter write to
+func (in *Interpreter) SetStdout(w io.Writer) {
+	in.stdout = w
+}
+
+// sets where message writes to
+func (in *Interpreter) SetStderr(w io.Writer) {
+	in.stderr = w
+}
+
+// reads a line from stdin without its line ending, giving up if the script is stopped while it waits
+func (in *Interpreter) readLine() (String, error) {
+	line, err := in.stdin.ReadLine(in.limits.ctx)
+	if errors.Is(err, io.EOF) {
+		return "", errors.New("there's no more input to read")
+	} else if err != nil {
+		if stopErr := in.stopped(); stopErr != nil {
+			return "", stopErr
+		}
+		return "", err
+	} else if err := in.alloc(addSize(valueHeaderSize, len(line))); err != nil {
+		return "", err
+	}
+	return String(line), nil
+}
+
+// lexes, parses and interprets Gor code, file is used in errors and to find modules that the code uses
+func (in *Interpreter) Run(text, file string) error {
+	return in.RunContext(context.Background(), text, file)
+}
+
+// like Run, but stops the script once ctx is cancelled
+func (in *Interpreter) RunContext(ctx context.Context, text, file string) error {
+	_, err := in.RunGor(ctx, text, file, RunOptions{})
+	return err
+}
@@ -0,0 +1,112 @@
+package gor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterFuncErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   any
+		want string
+	}{
+		{"1f", func() {}, "'1f' is not a valid Gor identifier"},
+		{"if", func() {}, "'if' is not a valid Gor identifier"},
+		{"f", nil, "cannot register nil as function 'f'"},
+		{"f", (func() int)(nil), "cannot register nil as function 'f'"},
+		{"f", 3, "cannot register function 'f': expected a function, but was given a value of Go type 'int'"},
+		{"f", func(m map[string]int) {}, "cannot register function 'f': parameter 1 has Go type 'map[string]int', which Gor values cannot be converted to"},
+		{"f", func(xs ...chan int) {}, "cannot register function 'f': parameter 1 has Go type 'chan int', which Gor values cannot be converted to"},
+		{"f", func() (int, int) { return 0, 0 }, "cannot register function 'f': a function called from Gor can only return a value, an error, or a value and an error"},
+		{"f", func() (int, error, error) { return 0, nil, nil }, "cannot register function 'f': a function called from Gor can only return a value, an error, or a value and an error"},
+		{"f", func() map[string]int { return nil }, "cannot register function 'f': it returns Go type 'map[string]int', which cannot be turned into a Gor value"},
+		{"f", func() ([]struct{}, error) { return nil, nil }, "cannot register function 'f': it returns Go type '[]struct {}', which cannot be turned into a Gor value"},
+	}
+
+	for _, tt := range tests {
+		in := NewInterpreter()
+		if err := in.RegisterFunc(tt.name, tt.fn); err == nil {
+			t.Errorf("registering %q: expected error %q, but there wasn't one", tt.name, tt.want)
+		} else if err.Error() != tt.want {
+			t.Errorf("registering %q: expected error %q, but got %q", tt.name, tt.want, err.Error())
+		}
+	}
+}
+
+func TestRegisterFunc(t *testing.T) {
+	in, out := testInterpreter(strings.NewReader(""))
+	funcs := map[string]any{
+		"double":  func(n int) int { return n * 2 },
+		"join":    func(sep string, parts ...string) string { return strings.Join(parts, sep) },
+		"small":   func(n uint8) uint8 { return n },
+		"fail":    func() error { return errors.New("it failed") },
+		"conName": func(c *Container) string { return c.Type.Name },
+		"adder":   func(n int) func(int) int { return func(x int) int { return x + n } },
+		"nothing": func() *int { return nil },
+	}
+	for name, fn := range funcs {
+		if err := in.RegisterFunc(name, fn); err != nil {
+			t.Fatalf("registering %q: expected no error, but got %s", name, err)
+		}
+	}
+
+	if err := in.Run("con P { x }\nputs(double(4), join(\"-\", \"a\", \"b\"), small(255), conName(P(1)), adder(1)(2), nothing());", "test.gor"); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if got := out.String(); got != "8 a-b 255 P 3 NULL\n" {
+		t.Errorf("expected output %q, but got %q", "8 a-b 255 P 3 NULL\n", got)
+	}
+
+	expectErrs := []struct{ src, want string }{
+		{"double(\"a\");", "error on line 1, col 1-6: argument 1 of function 'double' must be of type 'int', but was given 'string'"},
+		{"double();", "error on line 1, col 1-6: function 'double' expects 1 arguments, but was given 0"},
+		{"join();", "error on line 1, col 1-4: function 'join' expects at least 1 arguments, but was given 0"},
+		{"small(300);", "error on line 1, col 1-5: argument 1 of function 'small' is too big: 300 doesn't fit in the Go type 'uint8'"},
+		{"conName(1);", "error on line 1, col 1-7: argument 1 of function 'conName' must be of type 'container', but was given 'int'"},
+		{"fail();", "error on line 1, col 1-4: it failed"},
+	}
+	for _, tt := range expectErrs {
+		if err := in.Run(tt.src, "test.gor"); err == nil {
+			t.Errorf("running %q: expected error %q, but there wasn't one", tt.src, tt.want)
+		} else if err.Error() != tt.want {
+			t.Errorf("running %q: expected error %q, but got %q", tt.src, tt.want, err.Error())
+		}
+	}
+}
+
+func TestGlobals(t *testing.T) {
+	in, out := testInterpreter(strings.NewReader(""))
+	if err := in.SetGlobal("nf", (func() int)(nil)); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if err := in.SetGlobal("xs", []int{1, 2}); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	}
+
+	if err := in.Run("puts(nf, xs);\ny <- xs * 2;", "test.gor"); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if got := out.String(); got != "NULL [1] 1 2\n" {
+		t.Errorf("expected output %q, but got %q", "NULL [1] 1 2\n", got)
+	}
+
+	y, ok := in.GetGlobal("y")
+	if !ok {
+		t.Fatal("expected global 'y' to exist")
+	} else if got := ToGo(y); len(got.([]any)) != 2 || got.([]any)[1] != 4 {
+		t.Errorf("expected y to be [2 4], but got %v", got)
+	}
+
+	for _, tt := range []struct {
+		name string
+		val  any
+		want string
+	}{
+		{"puts", 1, "cannot set global 'puts' as it's the name of a builtin"},
+		{"a b", 1, "'a b' is not a valid Gor identifier"},
+		{"m", map[int]int{}, "cannot set global 'm': cannot turn a value of Go type 'map[int]int' into a Gor value"},
+	} {
+		if err := in.SetGlobal(tt.name, tt.val); err == nil || err.Error() != tt.want {
+			t.Errorf("setting global %q: expected error %q, but got %v", tt.name, tt.want, err)
+		}
+	}
+}
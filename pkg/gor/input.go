@@ -0,0 +1,82 @@
+package gor
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// reads input a line at a time for the input builtins.
+// it buffers what it reads, so everything reading from the same input has to share one
+type LineReader struct {
+	r *bufio.Reader
+	// held while reading, it's a channel so waiting for it can be given up on
+	lock chan struct{}
+	// a read that was given up on when its context was cancelled, the next ReadLine gets its line
+	pending chan lineResult
+}
+
+type lineResult struct {
+	line string
+	err  error
+}
+
+func NewLineReader(r io.Reader) *LineReader {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &LineReader{r: br, lock: make(chan struct{}, 1)}
+}
+
+var stdin = sync.OnceValue(func() *LineReader {
+	return NewLineReader(os.Stdin)
+})
+
+// the LineReader for os.Stdin, which every interpreter reads from unless it's given another stdin
+func Stdin() *LineReader {
+	return stdin()
+}
+
+// reads a line without its line ending, the last line of the input doesn't need one.
+// io.EOF is only returned once there's nothing left to read.
+// if ctx is cancelled it gives up waiting, but the line is still read and the next ReadLine gets it
+func (lr *LineReader) ReadLine(ctx context.Context) (string, error) {
+	select {
+	case lr.lock <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-lr.lock }()
+
+	if lr.pending == nil {
+		if ctx.Done() == nil {
+			res := lr.read()
+			return res.line, res.err
+		}
+
+		lr.pending = make(chan lineResult, 1)
+		go func(pending chan<- lineResult) {
+			pending <- lr.read()
+		}(lr.pending)
+	}
+
+	select {
+	case res := <-lr.pending:
+		lr.pending = nil
+		return res.line, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (lr *LineReader) read() lineResult {
+	line, err := lr.r.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return lineResult{err: err}
+	}
+	return lineResult{line: strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")}
+}
@@ -0,0 +1,750 @@
+package gor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+/*
+func SprintRS(str string, a ...any) string {
+	interopCount := strings.Count(str, "{}")
+	if interopCount != len(a) {
+		panic(fmt.Sprintf("expected %d substituitions, but was given %d instead", interopCount, len(a)))
+	}
+
+	for {
+		ind := strings.Index(str, "{}")
+		if ind == -1 {
+			return str
+		}
+	}
+}
+*/
+
+func isFile404Err(err string) bool {
+	return strings.HasPrefix(err, "open ") && strings.HasSuffix(err, ": no such file or directory")
+}
+
+// finds the module that tok imports, looking next to the file using it first and then in the policy's module directories
+func findModule(tok Token, file string, in *Interpreter) (string, string, error) {
+	name := tok.Lit
+	if pathExt := path.Ext(name); pathExt == "" {
+		name += ".gor"
+	} else if pathExt != ".gor" {
+		return "", "", NewGorError(tok, fmt.Sprintf("path '%s' is not a Gor file", path.Join(path.Dir(file), name)))
+	}
+
+	candidates := []string{path.Join(path.Dir(file), name)}
+	for _, dir := range in.policy.ModuleDirs {
+		candidates = append(candidates, path.Join(dir, name))
+	}
+
+	denied := false
+	for _, modpath := range candidates {
+		// files outside of the module directories aren't read at all, so scripts can't find out which ones exist
+		if !in.policy.allowsModule(modpath) {
+			denied = true
+			continue
+		}
+
+		content, err := readFile(modpath)
+		if err == nil {
+			return modpath, content, nil
+		} else if !isFile404Err(err.Error()) {
+			return "", "", NewGorError(tok, err.Error())
+		}
+	}
+
+	if denied {
+		return "", "", &GorError{Tok: tok, Msg: fmt.Sprintf("%s: module '%s' isn't in any of the directories this script can import modules from", ErrPermissionDenied, tok.Lit), Err: ErrPermissionDenied}
+	}
+	return "", "", NewGorError(tok, fmt.Sprintf("module '%s' does not exist", candidates[0]))
+}
+
+func importModule(tok Token, file string, in *Interpreter, printVars, printVarsEachCycle bool) (ModuleImport, error) {
+	modpath, modcontent, err := findModule(tok, file, in)
+	if err != nil {
+		return ModuleImport{}, err
+	} else if err := in.enterImport(tok, modpath); err != nil {
+		return ModuleImport{}, err
+	}
+	defer in.leaveImport()
+
+	mod, modErr := newModuleInterpreter(in).RunGor(in.limits.ctx, modcontent, modpath, RunOptions{PrintVars: printVars, PrintVarsEachCycle: printVarsEachCycle})
+	if modErr != nil {
+		return ModuleImport{}, modErr
+	}
+
+	return mod, nil
+}
+
+// an error in Gor code, carrying the token where it happened
+type GorError struct {
+	Tok Token
+	Msg string
+	// what caused the error if it came from outside of the Gor code, like a cancelled context
+	Err error
+}
+
+func (e *GorError) Error() string {
+	if e.Tok.Col == e.Tok.EndCol {
+		return fmt.Sprintf("error on line %d, col %d: %s", e.Tok.Ln, e.Tok.Col, e.Msg)
+	}
+	return fmt.Sprintf("error on line %d, col %d-%d: %s", e.Tok.Ln, e.Tok.Col, e.Tok.EndCol, e.Msg)
+}
+
+func (e *GorError) Unwrap() error {
+	return e.Err
+}
+
+func NewGorError(t Token, msg string) error {
+	return &GorError{Tok: t, Msg: msg}
+}
+
+// assigns the value to the variable, or to the variable outside of the current function if super is true
+func assignVar(env *Environment, in *Interpreter, identTok Token, value Value, super bool) error {
+	if existing, exists := in.funcs[identTok.Lit]; exists {
+		if _, isFn := value.(*Function); isFn {
+			return NewGorError(identTok, fmt.Sprintf("cannot redefine builtin function '%s'", identTok.Lit))
+		} else if _, isFn := existing.(*Function); isFn {
+			return NewGorError(identTok, fmt.Sprintf("cannot assign value '%s' to function '%s'", value, identTok.Lit))
+		}
+		return NewGorError(identTok, fmt.Sprintf("cannot assign value '%s' to container '%s'", value, identTok.Lit))
+	}
+
+	if fn, ok := value.(*Function); ok && fn.Name == "" {
+		fn.Name = identTok.Lit
+	}
+
+	if super {
+		env.SetSuper(identTok.Lit, value)
+	} else {
+		env.Set(identTok.Lit, value)
+	}
+	return nil
+}
+
+func callFunc(env *Environment, in *Interpreter, identTok Token, args []Value) (Value, error) {
+	fn, ok := env.Get(identTok.Lit)
+	if !ok {
+		fn, ok = in.funcs[identTok.Lit]
+		if !ok {
+			return nil, NewGorError(identTok, fmt.Sprintf("unknown function '%s'", identTok.Lit))
+		}
+	}
+	return callValue(fn, in, identTok, args)
+}
+
+// calls a function or creates a container, identTok is the name it was called by
+func callValue(fn Value, in *Interpreter, identTok Token, args []Value) (Value, error) {
+	switch f := fn.(type) {
+	case *Function:
+		if err := in.policy.checkCall(f, identTok); err != nil {
+			return nil, err
+		} else if f.Native != nil {
+			return callNativeFunc(f, identTok, args)
+		}
+		return callGorFunc(f, in, identTok, args)
+	case ContainerType:
+		if err := in.allocAt(identTok, addSize(objectSize, mulSize(len(args), valueHeaderSize))); err != nil {
+			return nil, err
+		}
+		return f.New(identTok, args)
+	}
+	return nil, NewGorError(identTok, fmt.Sprintf("'%s' is not a function", identTok.Lit))
+}
+
+func callNativeFunc(fn *Function, identTok Token, args []Value) (Value, error) {
+	fnVal := reflect.ValueOf(fn.Native)
+	fnType := fnVal.Type()
+	paramCount := fnType.NumIn()
+	if fnType.IsVariadic() {
+		if len(args) < paramCount-1 {
+			return nil, NewGorError(identTok, fmt.Sprintf("function '%s' expects at least %d arguments, but was given %d", identTok.Lit, paramCount-1, len(args)))
+		}
+	} else if len(args) != paramCount {
+		return nil, NewGorError(identTok, fmt.Sprintf("function '%s' expects %d arguments, but was given %d", identTok.Lit, paramCount, len(args)))
+	}
+
+	params := make([]reflect.Value, len(args))
+	for i, a := range args {
+		var paramType reflect.Type
+		if fnType.IsVariadic() && i >= paramCount-1 {
+			paramType = fnType.In(paramCount - 1).Elem()
+		} else {
+			paramType = fnType.In(i)
+		}
+
+		argVal, err := toGoParam(a, paramType)
+		if errors.Is(err, errParamType) {
+			return nil, NewGorError(identTok, fmt.Sprintf("argument %d of function '%s' must be of type '%s', but was given '%s'", i+1, identTok.Lit, paramTypeName(paramType), a.TypeName()))
+		} else if err != nil {
+			return nil, NewGorError(identTok, fmt.Sprintf("argument %d of function '%s' is too big: %s", i+1, identTok.Lit, err))
+		}
+		params[i] = argVal
+	}
+
+	out := fnVal.Call(params)
+	if len(out) > 0 && fnType.Out(len(out)-1) == errorType {
+		if err, ok := out[len(out)-1].Interface().(error); ok && err != nil {
+			return nil, &GorError{Tok: identTok, Msg: err.Error(), Err: err}
+		}
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 {
+		return Null{}, nil
+	}
+
+	res, err := FromGo(out[0].Interface())
+	if err != nil {
+		return nil, NewGorError(identTok, fmt.Sprintf("function '%s' returned a value Gor can't use: %s", identTok.Lit, err))
+	}
+	return res, nil
+}
+
+func addLabel(labels *map[string]uint, i uint, nameTok Token) error {
+	if _, ok := (*labels)[nameTok.Lit]; ok {
+		return NewGorError(nameTok, fmt.Sprintf("cannot create label '%v' as it already exists", nameTok.Lit))
+	}
+
+	(*labels)[nameTok.Lit] = i
+
+	return nil
+}
+
+func labelJump(i *uint, labelTok Token, labels map[string]uint) error {
+	if _, ok := labels[labelTok.Lit]; !ok {
+		return NewGorError(labelTok, fmt.Sprintf("cannot jump to label '%v' as it doesn't exist", labelTok.Lit))
+	}
+
+	*i = labels[labelTok.Lit]
+
+	return nil
+}
+
+func declareContainer(in *Interpreter, node ContainerDeclNode) error {
+	ct := ContainerType{Name: node.Name.Lit}
+	for _, f := range node.Fields {
+		ct.Fields = append(ct.Fields, f.Lit)
+	}
+
+	if existing, ok := in.funcs[node.Name.Lit]; ok {
+		existingCt, isCon := existing.(ContainerType)
+		if !isCon {
+			return NewGorError(node.Name, fmt.Sprintf("cannot declare container '%s' as a function with that name already exists", node.Name.Lit))
+		} else if !slices.Equal(existingCt.Fields, ct.Fields) {
+			// the containers made from the old declaration would still have its fields, so it can only be declared again the same way
+			return NewGorError(node.Name, fmt.Sprintf("cannot declare container '%s' as a container with that name and different fields already exists", node.Name.Lit))
+		}
+	}
+
+	in.funcs[node.Name.Lit] = ct
+	return nil
+}
+
+func assignField(env *Environment, identTok Token, fieldPath []Token, value Value) error {
+	val, ok := env.Get(identTok.Lit)
+	if !ok {
+		return NewGorError(identTok, fmt.Sprintf("unknown variable '%s'", identTok.Lit))
+	}
+	con, ok := val.(*Container)
+	if !ok {
+		return NewGorError(fieldPath[0], fmt.Sprintf("cannot access field '%s' of a value of type '%s'", fieldPath[0].Lit, val.TypeName()))
+	}
+	return con.SetPath(fieldPath, value)
+}
+
+func deleteVar(env *Environment, in *Interpreter, identTok Token) error {
+	if env.Delete(identTok.Lit) {
+		return nil
+	} else if existing, exists := in.funcs[identTok.Lit]; exists {
+		if _, isFn := existing.(*Function); isFn {
+			return NewGorError(identTok, fmt.Sprintf("cannot delete builtin function '%s'", identTok.Lit))
+		}
+		return NewGorError(identTok, fmt.Sprintf("cannot delete container '%s'", identTok.Lit))
+	}
+	return NewGorError(identTok, fmt.Sprintf("unknown variable '%s'", identTok.Lit))
+}
+
+// runs a delete statement, the parser has already checked that the target is something which can be deleted
+func runDelete(env *Environment, in *Interpreter, node DeleteNode) error {
+	switch target := node.Target.(type) {
+	case ValueNode:
+		return deleteVar(env, in, target.Val)
+	case FieldAccessNode:
+		val, err := target.Value.Generate(env, in)
+		if err != nil {
+			return err
+		}
+		con, ok := val.(*Container)
+		if !ok {
+			return NewGorError(target.Field, fmt.Sprintf("cannot access field '%s' of a value of type '%s'", target.Field.Lit, val.TypeName()))
+		}
+		return con.Delete(target.Field)
+	}
+
+	target := node.Target.(IndexNode)
+
+	// a vector in a container field is looked up through its container, so the container is only generated once
+	var con *Container
+	var val Value
+	if field, ok := target.Value.(FieldAccessNode); ok {
+		conVal, err := field.Value.Generate(env, in)
+		if err != nil {
+			return err
+		}
+		con, ok = conVal.(*Container)
+		if !ok {
+			return NewGorError(field.Field, fmt.Sprintf("cannot access field '%s' of a value of type '%s'", field.Field.Lit, conVal.TypeName()))
+		}
+		if val, err = con.Get(field.Field); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if val, err = target.Value.Generate(env, in); err != nil {
+			return err
+		}
+	}
+
+	index, err := target.Index.Generate(env, in)
+	if err != nil {
+		return err
+	}
+	vec, ok := val.(Vector)
+	if !ok {
+		return NewGorError(target.Bracket, fmt.Sprintf("cannot delete elements of a value of type '%s'", val.TypeName()))
+	}
+	res, err := vec.Remove(index)
+	if err != nil {
+		return NewGorError(target.Bracket, err.Error())
+	}
+
+	// the shortened vector is put back where it came from
+	if con != nil {
+		return con.SetPath([]Token{target.Value.(FieldAccessNode).Field}, res)
+	}
+	return assignVar(env, in, target.Value.(ValueNode).Val, res, false)
+}
+
+func callGorFunc(fn *Function, in *Interpreter, identTok Token, args []Value) (Value, error) {
+	if len(args) != len(fn.Params) {
+		return nil, NewGorError(identTok, fmt.Sprintf("function '%s' expects %d arguments, but was given %d", identTok.Lit, len(fn.Params), len(args)))
+	} else if err := in.enterCall(identTok); err != nil {
+		return nil, err
+	}
+	defer in.leaveCall()
+
+	locals := NewEnvironment(fn.Closure, true)
+	for i, p := range fn.Params {
+		locals.Define(p.Lit, args[i])
+	}
+
+	defer in.enterFile(fn.File)()
+	sig, err := runNodes(fn.Body, fn.File, locals, in, false, false)
+	if err != nil {
+		return nil, err
+	} else if sig == nil {
+		return Null{}, nil
+	} else if sig.Kind != returnSignal {
+		return nil, unhandledSignalError(sig)
+	} else if sig.Value == nil {
+		return Null{}, nil
+	}
+	return sig.Value, nil
+}
+
+func evalCondition(tok Token, expr AssignableValue, env *Environment, in *Interpreter) (bool, error) {
+	res, err := expr.Generate(env, in)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := res.Truthy()
+	if err != nil {
+		return false, NewGorError(tok, err.Error())
+	}
+	return b, nil
+}
+
+// returns the body of the first branch in the if/elsif/else chain whose condition is true
+func chooseIfBranch(n IfStatementNode, env *Environment, in *Interpreter) ([]Node, error) {
+	ok, err := evalCondition(n.Tok, n.Expr, env, in)
+	if err != nil {
+		return nil, err
+	} else if ok {
+		return n.Nodes, nil
+	}
+
+	for _, elsif := range n.Elsifs {
+		ok, err := evalCondition(elsif.Tok, elsif.Expr, env, in)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			return elsif.Nodes, nil
+		}
+	}
+
+	if n.Else != nil {
+		return n.Else.Nodes, nil
+	}
+	return nil, nil
+}
+
+type signalKind int
+
+const (
+	returnSignal signalKind = iota
+	jumpSignal
+	breakSignal
+	nextSignal
+)
+
+// returned by runNodes when execution has to leave the current block
+type controlSignal struct {
+	Kind  signalKind
+	Tok   Token
+	Value Value
+}
+
+// the error for a signal that got all the way up to a function or the top of the file without being handled
+func unhandledSignalError(sig *controlSignal) error {
+	switch sig.Kind {
+	case jumpSignal:
+		return NewGorError(sig.Tok, fmt.Sprintf("cannot jump to label '%s' as it doesn't exist in the current block or any block around it", sig.Tok.Lit))
+	case returnSignal:
+		return NewGorError(sig.Tok, "cannot return outside of a function")
+	}
+	return NewGorError(sig.Tok, fmt.Sprintf("cannot use '%s' outside of a loop", sig.Tok.Lit))
+}
+
+// moves i to the label a jump signal is going to if it's in this block, otherwise the signal is passed back up
+func catchJump(sig *controlSignal, i *uint, labels map[string]uint) *controlSignal {
+	if sig == nil || sig.Kind != jumpSignal {
+		return sig
+	} else if err := labelJump(i, sig.Tok, labels); err != nil {
+		return sig
+	}
+	return nil
+}
+
+// runs one iteration of a loop, returning whether the loop should stop and any signal that has to be passed further up
+func runLoopBody(tok Token, body []Node, file string, env *Environment, in *Interpreter, printVars, printVarsEachCycle bool) (bool, *controlSignal, error) {
+	// a loop with an empty body still has to be stoppable
+	if err := in.step(tok); err != nil {
+		return true, nil, err
+	}
+
+	sig, err := runNodes(body, file, env, in, printVars, printVarsEachCycle)
+	if err != nil {
+		return true, nil, err
+	} else if sig == nil || sig.Kind == nextSignal {
+		return false, nil, nil
+	} else if sig.Kind == breakSignal {
+		return true, nil, nil
+	}
+	return true, sig, nil
+}
+
+// the values a for loop goes through
+func loopValues(tok Token, iter Value) ([]Value, error) {
+	switch v := iter.(type) {
+	case Vector:
+		return v.Elems, nil
+	case Null:
+		return nil, nil
+	case Int, Float, String, Bool:
+		return []Value{v}, nil
+	}
+	return nil, NewGorError(tok, fmt.Sprintf("cannot loop over a value of type '%s'", iter.TypeName()))
+}
+
+func runNodes(nodes []Node, file string, env *Environment, in *Interpreter, printVars, printVarsEachCycle bool) (*controlSignal, error) {
+	var labels = make(map[string]uint)
+
+	for i, node := range nodes {
+		if n, ok := node.(LabelNode); ok {
+			err := addLabel(&labels, uint(i), n.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var i uint = 0
+	for i < uint(len(nodes)) {
+		node := nodes[i]
+		if err := in.step(nodeToken(node)); err != nil {
+			return nil, err
+		}
+
+		if n, ok := node.(AssignmentNode); ok {
+			val, err := n.Value.Generate(env, in)
+			if err != nil {
+				return nil, err
+			}
+			err = assignVar(env, in, n.Ident, val, n.Super)
+			if err != nil {
+				return nil, err
+			}
+			i++
+		} else if n, ok := node.(FieldAssignmentNode); ok {
+			val, err := n.Value.Generate(env, in)
+			if err != nil {
+				return nil, err
+			}
+			err = assignField(env, n.Ident, n.Path, val)
+			if err != nil {
+				return nil, err
+			}
+			i++
+		} else if n, ok := node.(DeleteNode); ok {
+			if err := runDelete(env, in, n); err != nil {
+				return nil, err
+			}
+			i++
+		} else if n, ok := node.(ContainerDeclNode); ok {
+			err := declareContainer(in, n)
+			if err != nil {
+				return nil, err
+			}
+			i++
+		} else if n, ok := node.(FunccallNode); ok {
+			_, err := n.Generate(env, in)
+			if err != nil {
+				return nil, err
+			}
+			i++
+		} else if n, ok := node.(CallNode); ok {
+			_, err := n.Generate(env, in)
+			if err != nil {
+				return nil, err
+			}
+			i++
+		} else if n, ok := node.(ReturnNode); ok {
+			if n.Value == nil {
+				return &controlSignal{Kind: returnSignal, Tok: n.Tok}, nil
+			}
+			res, err := n.Value.Generate(env, in)
+			if err != nil {
+				return nil, err
+			}
+			return &controlSignal{Kind: returnSignal, Tok: n.Tok, Value: res}, nil
+		} else if n, ok := node.(LoopControlNode); ok {
+			if n.Tok.Lit == "break" {
+				return &controlSignal{Kind: breakSignal, Tok: n.Tok}, nil
+			}
+			return &controlSignal{Kind: nextSignal, Tok: n.Tok}, nil
+		} else if n, ok := node.(WhileNode); ok {
+			for {
+				cond, err := evalCondition(n.Tok, n.Expr, env, in)
+				if err != nil {
+					return nil, err
+				} else if !cond {
+					break
+				}
+
+				stop, sig, err := runLoopBody(n.Tok, n.Nodes, file, NewEnvironment(env, false), in, printVars, printVarsEachCycle)
+				if err != nil {
+					return nil, err
+				} else if sig = catchJump(sig, &i, labels); sig != nil {
+					return sig, nil
+				} else if stop {
+					break
+				}
+			}
+			i++
+		} else if n, ok := node.(ForNode); ok {
+			if _, exists := in.funcs[n.Var.Lit]; exists {
+				return nil, NewGorError(n.Var, fmt.Sprintf("cannot use function '%s' as a loop variable", n.Var.Lit))
+			}
+			iter, err := n.Iter.Generate(env, in)
+			if err != nil {
+				return nil, err
+			}
+			values, err := loopValues(n.Tok, iter)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, v := range values {
+				// each iteration gets its own scope, so the loop variable doesn't outlive the loop
+				iterEnv := NewEnvironment(env, false)
+				iterEnv.Define(n.Var.Lit, v)
+
+				stop, sig, err := runLoopBody(n.Tok, n.Nodes, file, iterEnv, in, printVars, printVarsEachCycle)
+				if err != nil {
+					return nil, err
+				} else if sig = catchJump(sig, &i, labels); sig != nil {
+					return sig, nil
+				} else if stop {
+					break
+				}
+			}
+			i++
+		} else if n, ok := node.(RepeatNode); ok {
+			for {
+				stop, sig, err := runLoopBody(n.Tok, n.Nodes, file, NewEnvironment(env, false), in, printVars, printVarsEachCycle)
+				if err != nil {
+					return nil, err
+				} else if sig = catchJump(sig, &i, labels); sig != nil {
+					return sig, nil
+				} else if stop {
+					break
+				}
+			}
+			i++
+		} else if _, ok := node.(LabelNode); ok {
+			i++
+		} else if n, ok := node.(JumptoNode); ok {
+			sig := catchJump(&controlSignal{Kind: jumpSignal, Tok: n.LabelIdent}, &i, labels)
+			if sig != nil {
+				return sig, nil
+			}
+			i++
+		} else if n, ok := node.(ModuleImportNode); ok {
+			mod, err := importModule(n.PathIdent, file, in, printVars, printVarsEachCycle)
+			if err != nil {
+				return nil, err
+			}
+
+			for name, val := range mod.vars {
+				env.Define(name, val)
+			}
+			for name, fun := range mod.funcs {
+				in.funcs[name] = fun
+			}
+			i++
+		} else if n, ok := node.(IfStatementNode); ok {
+			branch, err := chooseIfBranch(n, env, in)
+			if err != nil {
+				return nil, err
+			}
+
+			sig, err := runNodes(branch, file, NewEnvironment(env, false), in, printVars, printVarsEachCycle)
+			if err != nil {
+				return nil, err
+			} else if sig = catchJump(sig, &i, labels); sig != nil {
+				return sig, nil
+			}
+			i++
+		} else {
+			return nil, errors.New("unknown node '" + reflect.TypeOf(node).Name() + "'")
+		}
+
+		if printVarsEachCycle {
+			fmt.Fprintln(in.stdout, env.Vars())
+			for vname, vval := range env.Vars() {
+				fmt.Fprintf(in.stdout, "'%s': %s, '%s'\n", vname, vval, vval.TypeName())
+			}
+			fmt.Fprintln(in.stdout, "")
+		}
+	}
+
+	return nil, nil
+}
+
+func (in *Interpreter) addBuiltins() {
+	in.funcs["puts"] = &Function{Name: "puts", Native: func(a ...Value) {
+		var strs []string
+		for _, v := range a {
+			strs = append(strs, v.String())
+		}
+		fmt.Fprintln(in.stdout, strings.Join(strs, " "))
+	}}
+	in.funcs["c"] = &Function{Name: "c", Native: func(args ...Value) (Value, error) {
+		size := valueHeaderSize
+		for _, a := range args {
+			size = addSize(size, sizeOf(a))
+		}
+		if err := in.alloc(size); err != nil {
+			return nil, err
+		}
+		return Combine(args...)
+	}}
+	in.funcs["seq"] = &Function{Name: "seq", Native: func(args ...Value) (Value, error) {
+		spec, err := parseSeq(args...)
+		if err != nil {
+			return nil, err
+		}
+		if err := in.alloc(mulSize(spec.length, scalarSize)); err != nil {
+			return nil, err
+		}
+		return in.buildVector(spec.length, spec.elem)
+	}}
+	in.funcs["bignum"] = &Function{Name: "bignum", Native: ToBigInt}
+	// '==' compares vectors element by element, so this is how to check if two whole values are the same
+	in.funcs["identical"] = &Function{Name: "identical", Native: func(a, b Value) Bool {
+		return Bool(a.Equals(b))
+	}}
+	in.funcs["length"] = &Function{Name: "length", Native: func(v Value) Int {
+		if vec, ok := v.(Vector); ok {
+			return Int(len(vec.Elems))
+		} else if _, ok := v.(Null); ok {
+			return 0
+		}
+		return 1
+	}}
+	// like R's message(), which writes to stderr so it doesn't get mixed up with the output of the script
+	in.funcs["message"] = &Function{Name: "message", Native: func(a ...Value) {
+		var strs []string
+		for _, v := range a {
+			strs = append(strs, v.String())
+		}
+		fmt.Fprintln(in.stderr, strings.Join(strs, ""))
+	}}
+	in.funcs["getStr"] = &Function{Name: "getStr", Needs: CapStdin, Native: func(prompt String) (String, error) {
+		fmt.Fprint(in.stdout, prompt)
+		return in.readLine()
+	}}
+}
+
+// adds getEnv and readFile, which aren't there unless the program asks for them as they let scripts read anything the process can.
+// the interpreter's policy still has to allow them before they can be called
+func (in *Interpreter) AddSystemFuncs() {
+	// like R's Sys.getenv(), which gives an empty string for variables that aren't set
+	in.funcs["getEnv"] = &Function{Name: "getEnv", Needs: CapEnv, Native: func(name String) String {
+		return String(os.Getenv(string(name)))
+	}}
+	in.funcs["readFile"] = &Function{Name: "readFile", Needs: CapFiles, Native: func(fileName String) (String, error) {
+		content, err := os.ReadFile(string(fileName))
+		if err != nil {
+			return "", err
+		}
+		return String(content), nil
+	}}
+}
+
+// runs the nodes with a new interpreter, stopping if ctx is cancelled
+func Interpret(ctx context.Context, nodes []Node, file string, opts RunOptions) (ModuleImport, error) {
+	return NewInterpreter().Interpret(ctx, nodes, file, opts)
+}
+
+// runs the nodes in the interpreter's global scope, stopping if ctx is cancelled or the script goes over the interpreter's limits
+func (in *Interpreter) Interpret(ctx context.Context, nodes []Node, file string, opts RunOptions) (ModuleImport, error) {
+	env := in.globals
+	defer in.startRun(ctx)()
+	defer in.enterFile(file)()
+
+	sig, err := runNodes(nodes, file, env, in, opts.PrintVars, opts.PrintVarsEachCycle)
+	if err != nil {
+		return ModuleImport{}, err
+	} else if sig != nil {
+		return ModuleImport{}, unhandledSignalError(sig)
+	}
+
+	if opts.PrintVars && !opts.PrintVarsEachCycle {
+		fmt.Fprintln(in.stdout, env.Vars())
+		for vname, vval := range env.Vars() {
+			fmt.Fprintf(in.stdout, "'%s': %s, '%s'\n", vname, vval, vval.TypeName())
+		}
+	}
+
+	return ModuleImport{vars: env.Vars(), funcs: in.funcs}, nil
+}
@@ -0,0 +1,139 @@
+package gor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runs the script and gives what it printed
+func runScript(t *testing.T, src string) (string, error) {
+	t.Helper()
+	in, out := testInterpreter(strings.NewReader(""))
+	err := in.Run(src, "test.gor")
+	return out.String(), err
+}
+
+// checks that each script prints what it should
+func expectOutputs(t *testing.T, tests []struct{ src, want string }) {
+	t.Helper()
+	for _, tt := range tests {
+		got, err := runScript(t, tt.src)
+		if err != nil {
+			t.Errorf("running %q: expected no error, but got %s", tt.src, err)
+		} else if got != tt.want {
+			t.Errorf("running %q: expected output %q, but got %q", tt.src, tt.want, got)
+		}
+	}
+}
+
+// checks that each script stops with the error it should
+func expectErrors(t *testing.T, tests []struct{ src, want string }) {
+	t.Helper()
+	for _, tt := range tests {
+		if _, err := runScript(t, tt.src); err == nil {
+			t.Errorf("running %q: expected error %q, but there wasn't one", tt.src, tt.want)
+		} else if err.Error() != tt.want {
+			t.Errorf("running %q: expected error %q, but got %q", tt.src, tt.want, err.Error())
+		}
+	}
+}
+
+func TestCallReturnedFunction(t *testing.T) {
+	expectOutputs(t, []struct{ src, want string }{
+		{"add <- func(n) { return func(x) { return x + n; }; };\nputs(add(5)(10));", "15\n"},
+		{"f <- func() { return func() { return func(x) { return x * 2; }; }; };\nputs(f()()(4));", "8\n"},
+		{"con P { x }\nf <- func() { return P; };\nputs(f()(3).x);", "3\n"},
+		{"f <- func() { return func() { puts(1); }; };\nf()();", "1\n"},
+	})
+
+	expectErrors(t, []struct{ src, want string }{
+		{"f <- func() { return 1; };\nf()();", "error on line 2, col 4: cannot call a value of type 'int'"},
+		{"f <- func() { return func(x) { return x; }; };\nf()();", "error on line 2, col 4: function '<anonymous>' expects 1 arguments, but was given 0"},
+	})
+}
+
+func TestUseInsideFunction(t *testing.T) {
+	root := t.TempDir()
+	lib := filepath.Join(root, "lib")
+	if err := os.Mkdir(lib, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(lib, "lib.gor"), "load <- func() {\n    use \"helper\"\n    return helperVal;\n}\n")
+	writeFile(t, filepath.Join(lib, "helper.gor"), "helperVal <- 42;")
+
+	in, out := testInterpreter(strings.NewReader(""))
+	// the helper is next to the file the function was written in, not the one calling it
+	if err := in.Run("use \"lib/lib\"\nputs(load());", filepath.Join(root, "main.gor")); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	} else if got := out.String(); got != "42\n" {
+		t.Errorf("expected output %q, but got %q", "42\n", got)
+	}
+}
+
+func TestUseSemicolon(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "vars.gor"), "one <- 1;")
+
+	// the ';' after use is optional, like after the other statements
+	for _, src := range []string{"use \"vars\";\nputs(one);", "use \"vars\"\nputs(one);", "use \"vars\"; puts(one);"} {
+		in, out := testInterpreter(strings.NewReader(""))
+		if err := in.Run(src, filepath.Join(root, "main.gor")); err != nil {
+			t.Errorf("running %q: expected no error, but got %s", src, err)
+		} else if got := out.String(); got != "1\n" {
+			t.Errorf("running %q: expected output %q, but got %q", src, "1\n", got)
+		}
+	}
+}
+
+func TestContainerRedeclaration(t *testing.T) {
+	expectOutputs(t, []struct{ src, want string }{
+		// declaring it again the same way is fine, which jumping back over a declaration does
+		{"con P { x }\ncon P { x }\nputs(P(1));", "P{x: 1}\n"},
+	})
+
+	expectErrors(t, []struct{ src, want string }{
+		{"con P { x }\np <- P(1);\ncon P { y }", "error on line 3, col 5: cannot declare container 'P' as a container with that name and different fields already exists"},
+		{"con P { x }\ncon P { x; y }", "error on line 2, col 5: cannot declare container 'P' as a container with that name and different fields already exists"},
+		{"con puts { x }", "error on line 1, col 5-8: cannot declare container 'puts' as a function with that name already exists"},
+	})
+}
+
+func TestNotPrecedence(t *testing.T) {
+	expectOutputs(t, []struct{ src, want string }{
+		{"x <- 1;\nputs(!x == 2);", "true\n"},
+		{"x <- 1;\nputs(!x < 2);", "false\n"},
+		{"puts(!true && false);", "false\n"},
+		{"puts(!false || false);", "true\n"},
+		{"puts(!(1 == 1) || true);", "true\n"},
+		{"puts(!!true);", "true\n"},
+		{"puts(1 == 1 && !1 == 2);", "true\n"},
+		{"puts(-2 * 3 == -6);", "true\n"},
+	})
+}
+
+func TestFunctionAsLoopVariable(t *testing.T) {
+	expectErrors(t, []struct{ src, want string }{
+		{"for (puts in c(1, 2)) {}", "error on line 1, col 6-9: cannot use function 'puts' as a loop variable"},
+		// it's an error even when there's nothing to loop over
+		{"for (puts in c()) {}", "error on line 1, col 6-9: cannot use function 'puts' as a loop variable"},
+	})
+}
+
+func TestIntegerOverflow(t *testing.T) {
+	expectOutputs(t, []struct{ src, want string }{
+		{`puts(bignum("0xFFFFFFFFFFFFFFFF"));`, "18446744073709551615\n"},
+		{`puts(bignum("-0b101"));`, "-5\n"},
+		// a leading 0 doesn't make it octal
+		{`puts(bignum("012"));`, "12\n"},
+		{"x <- -9223372036854775807 - 1;\nputs(-bignum(x));", "9223372036854775808\n"},
+	})
+
+	expectErrors(t, []struct{ src, want string }{
+		{"x <- -9223372036854775807 - 1;\nputs(-x);", "error on line 2, col 6: integer overflow, use bignum() for ints this big"},
+		{"x <- -c(1, 9223372036854775807 - 1 + 1, -9223372036854775807 - 1);", "error on line 1, col 6: integer overflow, use bignum() for ints this big"},
+		{`x <- -"a";`, "error on line 1, col 6: cannot use '-' on a value of type 'string'"},
+		{"x <- 0xFFFFFFFFFFFFFFFF;", `error on line 1, col 6-23: number '0xFFFFFFFFFFFFFFFF' is too big for an int, use bignum("0xFFFFFFFFFFFFFFFF") instead`},
+	})
+}
@@ -0,0 +1,622 @@
+package gor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// token types
+type tokType string
+
+const (
+	DOT   tokType = "DOT"
+	COMMA tokType = "COMMA"
+
+	LPAREN   tokType = "LPAREN"
+	RPAREN   tokType = "RPAREN"
+	LBRACKET tokType = "LBRACKET"
+	RBRACKET tokType = "RBRACKET"
+	LBRACE   tokType = "LBRACE"
+	RBRACE   tokType = "RBRACE"
+
+	PLUS          tokType = "PLUS"
+	HYPHEN        tokType = "HYPHEN"
+	ASTERISK      tokType = "ASTERISK"
+	FORWARD_SLASH tokType = "FORWARD_SLASH"
+	BACK_SLASH    tokType = "BACK_SLASH"
+	COLON         tokType = "COLON"
+	SEMICOLON     tokType = "SEMICOLON"
+	PERCENT_SIGN  tokType = "PERCENT_SIGN"
+
+	IDENT   tokType = "IDENT"
+	NUMBER  tokType = "NUMBER"
+	STRING  tokType = "STRING"
+	KEYWORD tokType = "KEYWORD"
+
+	AND          tokType = "AND"
+	OR           tokType = "OR"
+	EQUALS       tokType = "EQUALS"
+	NOT          tokType = "NOT"
+	NOT_EQUALS   tokType = "NOT_EQUALS"
+	LESSER_THAN  tokType = "LESSER_THAN"
+	GREATER_THAN tokType = "GREATER_THAN"
+
+	LESSER_EQUALS  tokType = "LESSER_EQUALS"
+	GREATER_EQUALS tokType = "GREATER_EQUALS"
+
+	ASSIGN        tokType = "ASSIGN"
+	SUPER_ASSIGN  tokType = "SUPER_ASSIGN"
+	RIGHT_ASSIGN  tokType = "RIGHT_ASSIGN"
+	PLUS_ASSIGN   tokType = "PLUS_ASSIGN"
+	MINUS_ASSIGN  tokType = "MINUS_ASSIGN"
+	TIMES_ASSIGN  tokType = "TIMES_ASSIGN"
+	DIVIDE_ASSIGN tokType = "DIVIDE_ASSIGN"
+
+	NEWLINE tokType = "NEWLINE"
+	COMMENT tokType = "COMMENT"
+	EOF     tokType = "EOF"
+
+	NULLTOKEN tokType = "NULLTOKEN"
+)
+
+var keywords = []string{
+	"func",
+	"con",
+	"delete",
+	"jumpto",
+	"if",
+	"elsif",
+	"else",
+	"use",
+	"return",
+	"true",
+	"false",
+	"while",
+	"for",
+	"in",
+	"repeat",
+	"break",
+	"next",
+}
+
+func isValidForIdent(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsMark(c) || c == '_'
+}
+
+// identifiers can't start with a digit or a combining mark
+func isValidIdentStart(c rune) bool {
+	return unicode.IsLetter(c) || c == '_'
+}
+
+// where a character is in the source, Idx is a byte offset while Col is counted in characters from the start of the line
+type Position struct {
+	Idx, Ln, Col int
+}
+
+type Token struct {
+	Type       tokType
+	Lit        string
+	Start, End int
+	Ln         int
+	// the columns of the first and last characters, starting from 1
+	Col, EndCol int
+}
+
+func (t Token) Length() int {
+	return (t.End - t.Start) + 1
+}
+
+func (t Token) IsMathOp() bool {
+	return t.Type == PLUS || t.Type == HYPHEN || t.Type == ASTERISK || t.Type == FORWARD_SLASH
+}
+
+func (t Token) Istype(_type tokType) bool {
+	return t.Type == _type
+}
+
+func NewToken(t tokType, literal string, start, end Position) Token {
+	endCol := end.Col
+	// a token spanning multiple lines, like a string, only gets the column it starts at
+	if end.Ln != start.Ln {
+		endCol = start.Col
+	}
+	return Token{Type: t, Lit: literal, Start: start.Idx, End: end.Idx, Ln: start.Ln, Col: start.Col, EndCol: endCol}
+}
+
+func NewNilToken(t tokType, start, end Position) Token {
+	return NewToken(t, "", start, end)
+}
+
+type Lexer struct {
+	text  string
+	cchar rune
+	// the byte width of cchar
+	size int
+	// the position of cchar and of the character before it
+	pos, last Position
+}
+
+func (l *Lexer) advance() {
+	l.last = l.pos
+	if l.cchar == '\n' {
+		l.pos.Ln++
+		l.pos.Col = 0
+	}
+	l.pos.Idx += l.size
+	l.pos.Col++
+
+	if l.pos.Idx < len(l.text) {
+		l.cchar, l.size = utf8.DecodeRuneInString(l.text[l.pos.Idx:])
+	} else {
+		l.cchar = rune(-1)
+		l.size = 0
+	}
+}
+
+// the character after the current one, without advancing
+func (l Lexer) peek() rune {
+	if next := l.pos.Idx + l.size; next < len(l.text) {
+		c, _ := utf8.DecodeRuneInString(l.text[next:])
+		return c
+	}
+	return rune(-1)
+}
+
+// a token from start up to and including the last character the lexer advanced past
+func (l *Lexer) tokenFrom(t tokType, literal string, start Position) Token {
+	end := l.last
+	if end.Idx < start.Idx {
+		end = start
+	}
+	return NewToken(t, literal, start, end)
+}
+
+// a token of the current character, advancing past it
+func (l *Lexer) single(t tokType, literal string) Token {
+	start := l.pos
+	l.advance()
+	return l.tokenFrom(t, literal, start)
+}
+
+func (l *Lexer) errorFrom(start Position, msg string) error {
+	return NewGorError(l.tokenFrom(NULLTOKEN, "", start), msg)
+}
+
+func (l *Lexer) errorHere(msg string) error {
+	return NewGorError(NewNilToken(NULLTOKEN, l.pos, l.pos), msg)
+}
+
+func (l Lexer) Lex() ([]Token, error) {
+	return l.LexContext(context.Background())
+}
+
+// like Lex, but stops once ctx is cancelled
+func (l Lexer) LexContext(ctx context.Context) ([]Token, error) {
+	// finding invalid UTF-8 upfront means the rest of the lexer never has to worry about it
+	for check := l; check.cchar != -1; check.advance() {
+		if check.cchar == utf8.RuneError && check.size == 1 {
+			return []Token{}, check.errorHere("invalid UTF-8 encoding")
+		}
+	}
+
+	var tokens []Token
+
+	for i := 0; l.cchar != -1; i++ {
+		if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return []Token{}, stoppedError(NewNilToken(NULLTOKEN, l.pos, l.pos), ctx.Err())
+		}
+
+		start := l.pos
+		switch l.cchar {
+		case ' ', '\t', '\r':
+			l.advance()
+		case '\n':
+			tokens = append(tokens, l.single(NEWLINE, "\n"))
+		case '(':
+			tokens = append(tokens, l.single(LPAREN, "("))
+		case ')':
+			tokens = append(tokens, l.single(RPAREN, ")"))
+		case '[':
+			tokens = append(tokens, l.single(LBRACKET, "["))
+		case ']':
+			tokens = append(tokens, l.single(RBRACKET, "]"))
+		case '{':
+			tokens = append(tokens, l.single(LBRACE, "{"))
+		case '}':
+			tokens = append(tokens, l.single(RBRACE, "}"))
+		case '.':
+			tokens = append(tokens, l.single(DOT, "."))
+		case ',':
+			tokens = append(tokens, l.single(COMMA, ","))
+		case '<':
+			l.advance()
+			if l.cchar == '-' {
+				l.advance()
+				tokens = append(tokens, l.tokenFrom(ASSIGN, "<-", start))
+			} else if l.cchar == '=' {
+				l.advance()
+				tokens = append(tokens, l.tokenFrom(LESSER_EQUALS, "<=", start))
+			} else if l.cchar == '<' && l.peek() == '-' {
+				l.advance()
+				l.advance()
+				tokens = append(tokens, l.tokenFrom(SUPER_ASSIGN, "<<-", start))
+			} else {
+				tokens = append(tokens, l.tokenFrom(LESSER_THAN, "<", start))
+			}
+		case '>':
+			l.advance()
+			if l.cchar == '=' {
+				l.advance()
+				tokens = append(tokens, l.tokenFrom(GREATER_EQUALS, ">=", start))
+			} else {
+				tokens = append(tokens, l.tokenFrom(GREATER_THAN, ">", start))
+			}
+		case '=':
+			l.advance()
+			if l.cchar != '=' {
+				tokens = append(tokens, l.tokenFrom(ASSIGN, "=", start))
+				continue
+			}
+			l.advance()
+			tokens = append(tokens, l.tokenFrom(EQUALS, "==", start))
+		case '!':
+			l.advance()
+			if l.cchar != '=' {
+				tokens = append(tokens, l.tokenFrom(NOT, "!", start))
+				continue
+			}
+			l.advance()
+			tokens = append(tokens, l.tokenFrom(NOT_EQUALS, "!=", start))
+		case '&', '|':
+			c := l.cchar
+			l.advance()
+			if l.cchar != c {
+				return []Token{}, l.errorFrom(start, fmt.Sprintf("illegal character '%c'", c))
+			}
+			l.advance()
+			if c == '&' {
+				tokens = append(tokens, l.tokenFrom(AND, "&&", start))
+			} else {
+				tokens = append(tokens, l.tokenFrom(OR, "||", start))
+			}
+		case '+', '-', '*', '/':
+			tokens = append(tokens, l.collectOperator())
+		case '\\':
+			tokens = append(tokens, l.single(BACK_SLASH, "\\"))
+		case ':':
+			tokens = append(tokens, l.single(COLON, ":"))
+		case ';':
+			tokens = append(tokens, l.single(SEMICOLON, ";"))
+		case '%':
+			tokens = append(tokens, l.single(PERCENT_SIGN, "%"))
+		case '?':
+			l.advance()
+			tokens = append(tokens, l.collectComment(start))
+		case '"':
+			l.advance()
+			tok, err := l.collectString(start)
+			if err != nil {
+				return []Token{}, err
+			}
+			tokens = append(tokens, tok)
+		default:
+			if l.cchar >= '0' && l.cchar <= '9' {
+				tok, err := l.collectNumber()
+				if err != nil {
+					return []Token{}, err
+				}
+				tokens = append(tokens, tok)
+			} else if (l.cchar == 'r' || l.cchar == 'R') && l.peek() == '"' {
+				tok, err := l.collectRawString()
+				if err != nil {
+					return []Token{}, err
+				}
+				tokens = append(tokens, tok)
+			} else if isValidIdentStart(l.cchar) {
+				tokens = append(tokens, l.collectIdent())
+			} else {
+				return []Token{}, l.errorHere(fmt.Sprintf("illegal character '%c'", l.cchar))
+			}
+		}
+	}
+
+	//tokens = append(tokens, NewNilToken(EOF, l.pos, l.pos))
+	return tokens, nil
+}
+
+var operators = map[rune]tokType{
+	'+': PLUS,
+	'-': HYPHEN,
+	'*': ASTERISK,
+	'/': FORWARD_SLASH,
+}
+
+var compoundAssigns = map[rune]tokType{
+	'+': PLUS_ASSIGN,
+	'-': MINUS_ASSIGN,
+	'*': TIMES_ASSIGN,
+	'/': DIVIDE_ASSIGN,
+}
+
+// collects an arithmetic operator, its compound assignment form like '+=', or '->'
+func (l *Lexer) collectOperator() Token {
+	start := l.pos
+	c := l.cchar
+	l.advance()
+
+	if l.cchar == '=' {
+		l.advance()
+		return l.tokenFrom(compoundAssigns[c], string(c)+"=", start)
+	} else if c == '-' && l.cchar == '>' {
+		l.advance()
+		return l.tokenFrom(RIGHT_ASSIGN, "->", start)
+	}
+	return l.tokenFrom(operators[c], string(c), start)
+}
+
+func (l *Lexer) collectComment(start Position) Token {
+	for l.cchar == ' ' {
+		l.advance()
+	}
+
+	from := l.pos.Idx
+	for l.cchar != -1 && l.cchar != '\n' {
+		l.advance()
+	}
+
+	return l.tokenFrom(COMMENT, l.text[from:l.pos.Idx], start)
+}
+
+func (l *Lexer) collectIdent() Token {
+	start := l.pos
+	for l.cchar != -1 && isValidForIdent(l.cchar) {
+		l.advance()
+	}
+	ident_str := l.text[start.Idx:l.pos.Idx]
+
+	if slices.Contains(keywords, ident_str) {
+		return l.tokenFrom(KEYWORD, ident_str, start)
+	}
+	return l.tokenFrom(IDENT, ident_str, start)
+}
+
+var escapes = map[rune]rune{
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+	'0':  0,
+	'a':  '\a',
+	'b':  '\b',
+	'f':  '\f',
+	'v':  '\v',
+	'\\': '\\',
+	'"':  '"',
+	'\'': '\'',
+}
+
+func (l *Lexer) collectString(start Position) (Token, error) {
+	var string_str strings.Builder
+
+	for l.cchar != '"' {
+		if l.cchar == -1 {
+			return Token{}, NewGorError(NewNilToken(NULLTOKEN, start, start), "unterminated string")
+		} else if l.cchar == '\\' {
+			escStart := l.pos
+			l.advance()
+			c, err := l.collectEscape(escStart)
+			if err != nil {
+				return Token{}, err
+			}
+			string_str.WriteRune(c)
+			continue
+		}
+		string_str.WriteRune(l.cchar)
+		l.advance()
+	}
+
+	l.advance()
+
+	return l.tokenFrom(STRING, string_str.String(), start), nil
+}
+
+// reads the escape sequence after a backslash, leaving the lexer on the character after it
+func (l *Lexer) collectEscape(escStart Position) (rune, error) {
+	if c, ok := escapes[l.cchar]; ok {
+		l.advance()
+		return c, nil
+	}
+
+	var digits int
+	switch l.cchar {
+	case 'x':
+		digits = 2
+	case 'u':
+		digits = 4
+	case 'U':
+		digits = 8
+	case -1:
+		return 0, l.errorFrom(escStart, "unterminated string")
+	default:
+		c := l.cchar
+		l.advance()
+		return 0, l.errorFrom(escStart, fmt.Sprintf("unknown escape sequence '\\%c'", c))
+	}
+	l.advance()
+
+	// '\u{...}' can have anywhere from one to six digits
+	braced := digits == 4 && l.cchar == '{'
+	if braced {
+		digits = 6
+		l.advance()
+	}
+
+	hex := ""
+	for len(hex) < digits && strings.ContainsRune("0123456789abcdefABCDEF", l.cchar) {
+		hex += string(l.cchar)
+		l.advance()
+	}
+	if braced {
+		if l.cchar != '}' || hex == "" {
+			return 0, l.errorFrom(escStart, "expected '}' after the digits of a '\\u{...}' escape")
+		}
+		l.advance()
+	} else if len(hex) != digits {
+		return 0, l.errorFrom(escStart, fmt.Sprintf("expected %d hex digits in escape sequence", digits))
+	}
+
+	code, _ := strconv.ParseUint(hex, 16, 32)
+	if code > unicode.MaxRune || (code >= 0xD800 && code <= 0xDFFF) {
+		return 0, l.errorFrom(escStart, fmt.Sprintf("'%s' is not a valid unicode code point", hex))
+	}
+	return rune(code), nil
+}
+
+// collects a raw string like R's r"(...)", which can have any number of dashes between the quote and the bracket
+func (l *Lexer) collectRawString() (Token, error) {
+	start := l.pos
+	l.advance()
+	l.advance()
+
+	dashes := ""
+	for l.cchar == '-' {
+		dashes += "-"
+		l.advance()
+	}
+
+	closers := map[rune]rune{'(': ')', '[': ']', '{': '}'}
+	closer, ok := closers[l.cchar]
+	if !ok {
+		return Token{}, l.errorHere("expected '(', '[' or '{' to start a raw string")
+	}
+	l.advance()
+
+	end := string(closer) + dashes + "\""
+	from := l.pos.Idx
+	for !strings.HasPrefix(l.text[l.pos.Idx:], end) {
+		if l.cchar == -1 {
+			return Token{}, NewGorError(NewNilToken(NULLTOKEN, start, start), "unterminated raw string")
+		}
+		l.advance()
+	}
+	string_str := l.text[from:l.pos.Idx]
+
+	for range end {
+		l.advance()
+	}
+
+	return l.tokenFrom(STRING, string_str, start), nil
+}
+
+func isDigitInBase(c rune, base int) bool {
+	switch base {
+	case 2:
+		return c == '0' || c == '1'
+	case 16:
+		return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+	}
+	return c >= '0' && c <= '9'
+}
+
+// collects digits of the given base, which can be separated by single underscores
+func (l *Lexer) collectDigits(base int) (string, error) {
+	from := l.pos.Idx
+	for isDigitInBase(l.cchar, base) || l.cchar == '_' {
+		if l.cchar == '_' && (l.pos.Idx == from || !isDigitInBase(l.peek(), base)) {
+			return "", l.errorHere("'_' in a number must be between two digits")
+		}
+		l.advance()
+	}
+	return l.text[from:l.pos.Idx], nil
+}
+
+// collects a number like '12', '1_000', '0.5', '1e-3', '0x1F', '0b1010' or '5L'; the literal is kept as it was written
+func (l *Lexer) collectNumber() (Token, error) {
+	start := l.pos
+	num_str := ""
+
+	if l.cchar == '0' && strings.ContainsRune("xXbB", l.peek()) {
+		base := 16
+		if l.peek() == 'b' || l.peek() == 'B' {
+			base = 2
+		}
+		num_str += "0" + string(l.peek())
+		l.advance()
+		l.advance()
+
+		digits, err := l.collectDigits(base)
+		if err != nil {
+			return Token{}, err
+		} else if digits == "" {
+			return Token{}, l.errorFrom(start, fmt.Sprintf("expected digits after '%s'", num_str))
+		}
+		num_str += digits
+	} else {
+		digits, err := l.collectDigits(10)
+		if err != nil {
+			return Token{}, err
+		}
+		num_str += digits
+
+		if l.cchar == '.' {
+			l.advance()
+			if !isDigitInBase(l.cchar, 10) {
+				return Token{}, l.errorFrom(start, "expected a digit after '.' in number")
+			}
+			digits, err := l.collectDigits(10)
+			if err != nil {
+				return Token{}, err
+			}
+			num_str += "." + digits
+
+			if l.cchar == '.' {
+				return Token{}, l.errorHere("a number can only have one '.'")
+			}
+		}
+
+		if l.cchar == 'e' || l.cchar == 'E' {
+			num_str += string(l.cchar)
+			l.advance()
+			if l.cchar == '+' || l.cchar == '-' {
+				num_str += string(l.cchar)
+				l.advance()
+			}
+			if !isDigitInBase(l.cchar, 10) {
+				return Token{}, l.errorFrom(start, "expected a digit in the exponent of number")
+			}
+			digits, err := l.collectDigits(10)
+			if err != nil {
+				return Token{}, err
+			}
+			num_str += digits
+		}
+	}
+
+	if l.cchar == 'L' {
+		if strings.ContainsAny(num_str, ".eE") && !strings.HasPrefix(num_str, "0x") && !strings.HasPrefix(num_str, "0X") {
+			return Token{}, l.errorHere("'L' can only be used on whole numbers without a '.' or an exponent")
+		}
+		num_str += "L"
+		l.advance()
+	}
+
+	if isValidForIdent(l.cchar) {
+		return Token{}, l.errorHere(fmt.Sprintf("invalid character '%c' in number", l.cchar))
+	}
+
+	return l.tokenFrom(NUMBER, num_str, start), nil
+}
+
+func NewLexer(text string) Lexer {
+	lexer := Lexer{}
+
+	lexer.text = text
+	lexer.pos = Position{Idx: 0, Ln: 1, Col: 0}
+	lexer.advance()
+
+	return lexer
+}
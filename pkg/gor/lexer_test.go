@@ -0,0 +1,98 @@
+package gor
+
+import "testing"
+
+// lexes the source, which should be a single string
+func lexString(t *testing.T, src string) Token {
+	t.Helper()
+	tokens, err := Lex(src)
+	if err != nil {
+		t.Fatalf("lexing %q: %s", src, err)
+	}
+	if len(tokens) != 1 || !tokens[0].Istype(STRING) {
+		t.Fatalf("lexing %q: expected a single string token, but got %v", src, tokens)
+	}
+	return tokens[0]
+}
+
+func TestStringEscapes(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{`"\n"`, "\n"},
+		{`"\t"`, "\t"},
+		{`"\r"`, "\r"},
+		{`"\0"`, "\x00"},
+		{`"\a"`, "\a"},
+		{`"\b"`, "\b"},
+		{`"\f"`, "\f"},
+		{`"\v"`, "\v"},
+		{`"\\"`, "\\"},
+		{`"\""`, "\""},
+		{`"\'"`, "'"},
+		{`"\x41"`, "A"},
+		{`"\xe9"`, "é"},
+		{`"\u00e9"`, "é"},
+		{`"\U0001F600"`, "😀"},
+		{`"\u{1F600}"`, "😀"},
+		{`"\u{41}"`, "A"},
+		{`"a\tb\nc"`, "a\tb\nc"},
+	}
+
+	for _, tt := range tests {
+		if got := lexString(t, tt.src).Lit; got != tt.want {
+			t.Errorf("lexing %s: expected %q, but got %q", tt.src, tt.want, got)
+		}
+	}
+}
+
+func TestRawStrings(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{`r"(hello)"`, "hello"},
+		{`r"[hello]"`, "hello"},
+		{`r"{hello}"`, "hello"},
+		{`r"(C:\path\n)"`, `C:\path\n`},
+		{`r"("quoted")"`, `"quoted"`},
+		{`r"-(has )" inside)-"`, `has )" inside`},
+		{`r"--[a ]-" b]--"`, `a ]-" b`},
+		{"r\"(two\nlines)\"", "two\nlines"},
+		{`r"()"`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := lexString(t, tt.src).Lit; got != tt.want {
+			t.Errorf("lexing %s: expected %q, but got %q", tt.src, tt.want, got)
+		}
+	}
+}
+
+func TestStringErrors(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{`x <- "abc`, "error on line 1, col 6: unterminated string"},
+		{"x <- 1;\ny <- \"abc\ndef", "error on line 2, col 6: unterminated string"},
+		{`"abc\`, "error on line 1, col 5: unterminated string"},
+		{`"\q"`, "error on line 1, col 2-3: unknown escape sequence '\\q'"},
+		{`"\x4"`, "error on line 1, col 2-4: expected 2 hex digits in escape sequence"},
+		{`"\u12"`, "error on line 1, col 2-5: expected 4 hex digits in escape sequence"},
+		{`"\u{12"`, "error on line 1, col 2-6: expected '}' after the digits of a '\\u{...}' escape"},
+		{`"\u{}"`, "error on line 1, col 2-4: expected '}' after the digits of a '\\u{...}' escape"},
+		{`"\uD800"`, "error on line 1, col 2-7: 'D800' is not a valid unicode code point"},
+		{`"\u{110000}"`, "error on line 1, col 2-11: '110000' is not a valid unicode code point"},
+		{`r"(abc`, "error on line 1, col 1: unterminated raw string"},
+		{`r"-(abc)"`, "error on line 1, col 1: unterminated raw string"},
+		{`r"<abc>"`, "error on line 1, col 3: expected '(', '[' or '{' to start a raw string"},
+	}
+
+	for _, tt := range tests {
+		_, err := Lex(tt.src)
+		if err == nil {
+			t.Errorf("lexing %q: expected error %q, but there wasn't one", tt.src, tt.want)
+		} else if err.Error() != tt.want {
+			t.Errorf("lexing %q: expected error %q, but got %q", tt.src, tt.want, err.Error())
+		}
+	}
+}
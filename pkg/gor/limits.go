@@ -0,0 +1,310 @@
+package gor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrStepLimit      = errors.New("step limit reached")
+	ErrMemoryLimit    = errors.New("memory limit reached")
+	ErrCallDepthLimit = errors.New("call depth limit reached")
+)
+
+const (
+	// roughly how many bytes a value takes up before counting what it holds
+	valueHeaderSize = 16
+	// an int, float or bool, or a vector element holding one
+	scalarSize = valueHeaderSize + 8
+	// roughly what a function or container costs before counting what it holds
+	objectSize = 8 * valueHeaderSize
+
+	// how deep Gor functions can call each other by default, which is well before Go would run out of stack
+	defaultMaxCallDepth = 10_000
+	// how many vector elements are made between checks for the script being stopped
+	cancelCheckInterval = 1 << 16
+)
+
+// what stops a script that runs for too long or uses too much memory, shared with the interpreters of the modules it uses
+type runLimits struct {
+	ctx context.Context
+	// set while a script is running, so modules don't restart the count of the script using them
+	running bool
+
+	steps     uint64
+	maxSteps  uint64
+	allocated int
+	maxMemory int
+	depth     int
+	maxDepth  int
+	// the modules being imported right now, from the first to the latest, so a module that uses itself can be stopped
+	importing []string
+}
+
+// sets how many statements and loop iterations a run can go through before it's stopped, 0 means there's no limit
+func (in *Interpreter) SetStepLimit(steps uint64) {
+	in.limits.maxSteps = steps
+}
+
+// sets roughly how many bytes the values a run creates can add up to before it's stopped, 0 means there's no limit.
+// every vector, string, bignum, function and container a script makes counts, even ones it throws away later,
+// and it's checked before they're made so a huge one is stopped before it can use up the memory
+func (in *Interpreter) SetMemoryLimit(bytes int) {
+	in.limits.maxMemory = bytes
+}
+
+// sets how deep Gor functions can call each other, 0 means there's no limit.
+// the default is 10,000, without a limit deep enough recursion crashes the whole program
+func (in *Interpreter) SetCallDepthLimit(depth int) {
+	in.limits.maxDepth = depth
+}
+
+// starts counting towards the limits, the returned function has to be called once the run is done
+func (in *Interpreter) startRun(ctx context.Context) func() {
+	if in.limits.running {
+		return func() {}
+	}
+
+	in.limits.ctx, in.limits.running = ctx, true
+	in.limits.steps, in.limits.allocated, in.limits.depth = 0, 0, 0
+	in.limits.importing = nil
+	return func() {
+		in.limits.running = false
+	}
+}
+
+// the error for a script that was stopped from outside, or nil if it wasn't
+func (in *Interpreter) stopped() error {
+	if err := in.limits.ctx.Err(); err != nil {
+		return fmt.Errorf("the script was stopped: %w", err)
+	}
+	return nil
+}
+
+// the error for a script that was stopped from outside at tok, ctxErr is why the context was cancelled
+func stoppedError(tok Token, ctxErr error) error {
+	err := fmt.Errorf("the script was stopped: %w", ctxErr)
+	return &GorError{Tok: tok, Msg: err.Error(), Err: err}
+}
+
+// called before each statement and loop iteration, stops the script if it was cancelled or went over its step limit
+func (in *Interpreter) step(tok Token) error {
+	if err := in.limits.ctx.Err(); err != nil {
+		return stoppedError(tok, err)
+	}
+
+	in.limits.steps++
+	if in.limits.maxSteps > 0 && in.limits.steps > in.limits.maxSteps {
+		return &GorError{Tok: tok, Msg: fmt.Sprintf("the script was stopped after going over its limit of %d steps", in.limits.maxSteps), Err: ErrStepLimit}
+	}
+	return nil
+}
+
+// counts bytes the script is about to allocate towards its memory limit
+func (in *Interpreter) alloc(bytes int) error {
+	if in.limits.maxMemory <= 0 {
+		return nil
+	} else if bytes > in.limits.maxMemory-in.limits.allocated {
+		return fmt.Errorf("%w: the script would allocate more than its limit of %d bytes", ErrMemoryLimit, in.limits.maxMemory)
+	}
+	in.limits.allocated += bytes
+	return nil
+}
+
+// like alloc, but the error points at tok
+func (in *Interpreter) allocAt(tok Token, bytes int) error {
+	if err := in.alloc(bytes); err != nil {
+		return &GorError{Tok: tok, Msg: err.Error(), Err: err}
+	}
+	return nil
+}
+
+// called when a Gor function is called, leaveCall has to be called once it returns
+func (in *Interpreter) enterCall(tok Token) error {
+	if in.limits.maxDepth > 0 && in.limits.depth >= in.limits.maxDepth {
+		return &GorError{Tok: tok, Msg: fmt.Sprintf("the script was stopped as its functions went over the limit of %d calls deep", in.limits.maxDepth), Err: ErrCallDepthLimit}
+	}
+	in.limits.depth++
+	return nil
+}
+
+func (in *Interpreter) leaveCall() {
+	in.limits.depth--
+}
+
+// called when a module is imported, which counts towards the call depth limit like a call does.
+// leaveImport has to be called once it's done
+func (in *Interpreter) enterImport(tok Token, modpath string) error {
+	resolved, err := resolvePath(modpath)
+	if err != nil {
+		resolved = modpath
+	}
+
+	for i, importing := range in.limits.importing {
+		if importing == resolved {
+			cycle := append(slices.Clone(in.limits.importing[i:]), resolved)
+			return NewGorError(tok, fmt.Sprintf("cannot use module '%s' as it's already being imported, the modules use each other in a loop: %s", tok.Lit, strings.Join(cycle, " -> ")))
+		}
+	}
+
+	if err := in.enterCall(tok); err != nil {
+		return err
+	}
+	in.limits.importing = append(in.limits.importing, resolved)
+	return nil
+}
+
+func (in *Interpreter) leaveImport() {
+	in.limits.importing = in.limits.importing[:len(in.limits.importing)-1]
+	in.leaveCall()
+}
+
+// makes a vector of length elements, checking every so often that the script hasn't been stopped so a huge one doesn't have to finish first.
+// the vector has to be counted with alloc before it's made, as a big enough one would run out of memory before the limit could stop it
+func (in *Interpreter) buildVector(length int, elem func(i int) Value) (Vector, error) {
+	elems := make([]Value, 0, min(length, cancelCheckInterval))
+	for i := range length {
+		if i%cancelCheckInterval == 0 {
+			if err := in.stopped(); err != nil {
+				return Vector{}, err
+			}
+		}
+		elems = append(elems, elem(i))
+	}
+	return Vector{Elems: elems}, nil
+}
+
+// adds sizes together without overflowing
+func addSize(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// multiplies sizes together without overflowing
+func mulSize(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+// roughly how many bytes a value takes up
+func sizeOf(v Value) int {
+	switch val := v.(type) {
+	case String:
+		return valueHeaderSize + len(val)
+	case BigInt:
+		return valueHeaderSize + len(val.Val.Bits())*8
+	case Vector:
+		size := valueHeaderSize
+		for _, e := range val.Elems {
+			size = addSize(size, sizeOf(e))
+		}
+		return size
+	case *Function, *Container:
+		return objectSize
+	}
+	return scalarSize
+}
+
+// the length a value has when it's recycled in an operation with a vector
+func recycledLength(v Value) int {
+	if vec, ok := v.(Vector); ok {
+		return len(vec.Elems)
+	}
+	return 1
+}
+
+// the element of a value that an operation with a vector uses at i
+func recycledElem(v Value, i int) Value {
+	if vec, ok := v.(Vector); ok {
+		return vec.Elems[i%len(vec.Elems)]
+	}
+	return v
+}
+
+// roughly how many bytes the result of an operation will take up, which is worked out before the operation is done.
+// ints, floats and bools on their own aren't counted, as they can't use more memory than the values they came from
+func resultSize(op tokType, left, right Value) int {
+	_, leftVec := left.(Vector)
+	_, rightVec := right.(Vector)
+	if leftVec || rightVec {
+		l, r := recycledLength(left), recycledLength(right)
+		if l == 0 || r == 0 {
+			return valueHeaderSize
+		}
+
+		size := valueHeaderSize
+		for i := range max(l, r) {
+			size = addSize(size, max(scalarSize, resultSize(op, recycledElem(left, i), recycledElem(right, i))))
+		}
+		return size
+	}
+
+	switch op {
+	case EQUALS, NOT_EQUALS, GREATER_THAN, LESSER_THAN, GREATER_EQUALS, LESSER_EQUALS:
+		return 0
+	}
+
+	if s, ok := left.(String); ok {
+		switch r := right.(type) {
+		case String:
+			return addSize(valueHeaderSize, addSize(len(s), len(r)))
+		case Int:
+			if r > 0 {
+				return addSize(valueHeaderSize, mulSize(len(s), int(r)))
+			}
+		}
+	}
+
+	_, leftBig := left.(BigInt)
+	_, rightBig := right.(BigInt)
+	if leftBig || rightBig {
+		// multiplying gives a result as big as both sides put together, which is the most any operation can make
+		return addSize(sizeOf(left), sizeOf(right))
+	}
+	return 0
+}
+
+// a token to point errors at for the node
+func nodeToken(node Node) Token {
+	switch n := node.(type) {
+	case AssignmentNode:
+		return n.Ident
+	case FieldAssignmentNode:
+		return n.Ident
+	case FunccallNode:
+		return n.Ident
+	case CallNode:
+		return n.Paren
+	case ContainerDeclNode:
+		return n.Name
+	case LabelNode:
+		return n.Name
+	case JumptoNode:
+		return n.LabelIdent
+	case ModuleImportNode:
+		return n.PathIdent
+	case DeleteNode:
+		return n.Tok
+	case ReturnNode:
+		return n.Tok
+	case LoopControlNode:
+		return n.Tok
+	case IfStatementNode:
+		return n.Tok
+	case WhileNode:
+		return n.Tok
+	case ForNode:
+		return n.Tok
+	case RepeatNode:
+		return n.Tok
+	}
+	return Token{}
+}
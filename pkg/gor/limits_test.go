@@ -0,0 +1,222 @@
+package gor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// an interpreter that writes to a buffer instead of the process's streams
+func testInterpreter(stdin io.Reader) (*Interpreter, *bytes.Buffer) {
+	var out bytes.Buffer
+	in := NewInterpreter()
+	in.SetStdin(stdin)
+	in.SetStdout(&out)
+	in.SetStderr(&out)
+	return in, &out
+}
+
+// runs the script, failing if it takes longer than it should to stop
+func runWithin(t *testing.T, in *Interpreter, ctx context.Context, src string, within time.Duration) error {
+	t.Helper()
+	start := time.Now()
+	err := in.RunContext(ctx, src, "test.gor")
+	if took := time.Since(start); took > within {
+		t.Errorf("running %q took %s, but should've taken less than %s", src, took, within)
+	}
+	return err
+}
+
+// checks that err wraps target and is a GorError
+func expectError(t *testing.T, err, target error) {
+	t.Helper()
+	var gorErr *GorError
+	if !errors.Is(err, target) {
+		t.Errorf("expected an error wrapping %q, but got %v", target, err)
+	} else if !errors.As(err, &gorErr) {
+		t.Errorf("expected a GorError, but got %T: %s", err, err)
+	}
+}
+
+func TestStepLimit(t *testing.T) {
+	in, _ := testInterpreter(strings.NewReader(""))
+	in.SetStepLimit(1000)
+	expectError(t, runWithin(t, in, context.Background(), ":loop:\njumpto loop;", time.Second), ErrStepLimit)
+
+	// the count starts over for each run
+	if err := in.Run("x <- 0; while x < 100 { x += 1; }", "test.gor"); err != nil {
+		t.Errorf("expected no error, but got %s", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	in, _ := testInterpreter(strings.NewReader(""))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	expectError(t, runWithin(t, in, ctx, "while true {}", time.Second), context.DeadlineExceeded)
+}
+
+func TestCancelLongRange(t *testing.T) {
+	in, _ := testInterpreter(strings.NewReader(""))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	expectError(t, runWithin(t, in, ctx, "x <- 1:2000000000;", 2*time.Second), context.DeadlineExceeded)
+}
+
+func TestCancelWhileReading(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	in, out := testInterpreter(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	expectError(t, runWithin(t, in, ctx, `x <- getStr("> ");`, time.Second), context.DeadlineExceeded)
+
+	// the line the cancelled read was waiting for isn't lost
+	go w.Write([]byte("hello\n"))
+	if err := in.Run(`puts(getStr(""));`, "test.gor"); err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	}
+	if got := out.String(); got != "> hello\n" {
+		t.Errorf("expected output %q, but got %q", "> hello\n", got)
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	scripts := []string{
+		`x <- "abcd" * 500000000;`,
+		`x <- 1:100000000;`,
+		`x <- seq(1, 100000000);`,
+		`x <- 1:1000; repeat { x <- c(x, x); }`,
+		`x <- 1:1000; repeat { y <- x + 1; }`,
+		// every function and container counts, even when each one replaces the last
+		"con Box { f }\nv <- 1:100; b <- Box(0); repeat { b <- Box(func() { return v; }); }",
+		`s <- "ab"; repeat { s <- s + s; }`,
+	}
+
+	for _, src := range scripts {
+		in, _ := testInterpreter(strings.NewReader(""))
+		in.SetMemoryLimit(1 << 20)
+		expectError(t, runWithin(t, in, context.Background(), src, 5*time.Second), ErrMemoryLimit)
+	}
+}
+
+func TestMemoryLimitScalarLoop(t *testing.T) {
+	in, _ := testInterpreter(strings.NewReader(""))
+	in.SetMemoryLimit(1 << 20)
+	if err := runWithin(t, in, context.Background(), "x <- 0; i <- 0; while i < 20000 { x += i * 2; i += 1; }", time.Second); err != nil {
+		t.Errorf("expected no error, but got %s", err)
+	}
+}
+
+func TestCallDepthLimit(t *testing.T) {
+	src := "f <- func(n) { return f(n + 1); }; f(1);"
+
+	in, _ := testInterpreter(strings.NewReader(""))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	expectError(t, runWithin(t, in, ctx, src, 10*time.Second), ErrCallDepthLimit)
+
+	in.SetCallDepthLimit(50)
+	err := in.Run(src, "test.gor")
+	expectError(t, err, ErrCallDepthLimit)
+	if err != nil && !strings.Contains(err.Error(), "limit of 50 calls") {
+		t.Errorf("expected the error to mention the limit, but got %s", err)
+	}
+
+	// functions that return before the limit are fine
+	if err := in.Run("g <- func(n) { if n > 0 { return g(n - 1); } return 0; }; g(40);", "test.gor"); err != nil {
+		t.Errorf("expected no error, but got %s", err)
+	}
+}
+
+func TestImportCycle(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "self.gor"), "use \"self\"")
+	writeFile(t, filepath.Join(root, "a.gor"), "use \"b\"")
+	writeFile(t, filepath.Join(root, "b.gor"), "use \"a\"")
+
+	for _, src := range []string{"use \"self\"", "use \"a\""} {
+		in, _ := testInterpreter(strings.NewReader(""))
+		in.SetMemoryLimit(1 << 20)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := in.RunContext(ctx, src, filepath.Join(root, "main.gor"))
+		cancel()
+
+		var gorErr *GorError
+		if !errors.As(err, &gorErr) || !strings.Contains(err.Error(), "the modules use each other in a loop") {
+			t.Errorf("running %q: expected an error about the modules using each other, but got %v", src, err)
+		}
+	}
+}
+
+func TestImportDepth(t *testing.T) {
+	root := t.TempDir()
+	for i := range 5 {
+		writeFile(t, filepath.Join(root, fmt.Sprintf("m%d.gor", i)), fmt.Sprintf("use \"m%d\"", i+1))
+	}
+	writeFile(t, filepath.Join(root, "m5.gor"), "x <- 1;")
+
+	in, _ := testInterpreter(strings.NewReader(""))
+	in.SetCallDepthLimit(3)
+	expectError(t, in.Run("use \"m0\"", filepath.Join(root, "main.gor")), ErrCallDepthLimit)
+
+	in.SetCallDepthLimit(10)
+	if err := in.Run("use \"m0\"", filepath.Join(root, "main.gor")); err != nil {
+		t.Errorf("expected no error, but got %s", err)
+	}
+}
+
+func TestLexAndParseLimits(t *testing.T) {
+	fast := []string{
+		`x <- "` + strings.Repeat("a", 400_000) + `";`,
+		"? " + strings.Repeat("a", 400_000),
+		`x <- r"(` + strings.Repeat("a", 400_000) + `)";`,
+		"x <- " + strings.Repeat("1", 400_000) + "L;",
+		strings.Repeat("if true {\n", 4000) + "x <- 1;\n" + strings.Repeat("}\n", 4000),
+		"f <- " + strings.Repeat("func() { return ", 2000) + "1;" + strings.Repeat(" };", 2000),
+	}
+	for _, src := range fast {
+		in, _ := testInterpreter(strings.NewReader(""))
+		// only how long it takes matters, the number literal is too big for an int
+		runWithin(t, in, context.Background(), src, 2*time.Second)
+	}
+
+	deep := []string{
+		"x <- " + strings.Repeat("(", 100_000) + "1" + strings.Repeat(")", 100_000) + ";",
+		"x <- " + strings.Repeat("-", 100_000) + "1;",
+		"x <- " + strings.Repeat("!", 100_000) + "true;",
+		"x <- 1" + strings.Repeat(" + 1", 100_000) + ";",
+		"x <- c(1); y <- x" + strings.Repeat("[1]", 100_000) + ";",
+		strings.Repeat("while true {", 100_000) + strings.Repeat("}", 100_000),
+	}
+	for _, src := range deep {
+		in, _ := testInterpreter(strings.NewReader(""))
+		err := runWithin(t, in, context.Background(), src, 2*time.Second)
+		if err == nil || !strings.Contains(err.Error(), "the code is nested more than 10000 levels deep") {
+			t.Errorf("running %.20q...: expected an error about nesting, but got %v", src, err)
+		}
+	}
+}
+
+func TestCancelLexAndParse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	src := strings.Repeat("x <- 1;\n", 100_000)
+
+	_, err := NewLexer(src).LexContext(ctx)
+	expectError(t, err, context.Canceled)
+
+	tokens, err := Lex(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ParseContext(ctx, tokens)
+	expectError(t, err, context.Canceled)
+}
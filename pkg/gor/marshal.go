@@ -0,0 +1,293 @@
+package gor
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+var (
+	valueType  = reflect.TypeOf((*Value)(nil)).Elem()
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	bigIntType = reflect.TypeOf((*big.Int)(nil))
+)
+
+// the Gor names of the Value types that a native function's parameters can be,
+// which can't come from their TypeName methods as some of them need a value that isn't the zero one
+var valueTypeNames = map[reflect.Type]string{
+	reflect.TypeOf(BigInt{}):        "bignum",
+	reflect.TypeOf(Null{}):          "null",
+	reflect.TypeOf(Vector{}):        "vector",
+	reflect.TypeOf(&Function{}):     "function",
+	reflect.TypeOf(ContainerType{}): "con",
+	reflect.TypeOf(&Container{}):    "container",
+}
+
+// returned by toGoParam when the Gor value is the wrong type for the parameter
+var errParamType = errors.New("wrong type for the parameter")
+
+// turns a Go value into a Gor value, slices become vectors and functions become native functions
+func FromGo(x any) (Value, error) {
+	if x == nil {
+		return Null{}, nil
+	} else if v, ok := x.(Value); ok {
+		return v, nil
+	} else if b, ok := x.(*big.Int); ok {
+		if b == nil {
+			return Null{}, nil
+		}
+		return BigInt{Val: new(big.Int).Set(b)}, nil
+	}
+
+	rv := reflect.ValueOf(x)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u <= uint64(^uint(0)>>1) {
+			return Int(u), nil
+		}
+		return BigInt{Val: new(big.Int).SetUint64(rv.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		return Float(rv.Float()), nil
+	case reflect.String:
+		return String(rv.String()), nil
+	case reflect.Bool:
+		return Bool(rv.Bool()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return Null{}, nil
+		}
+		elems := make([]Value, rv.Len())
+		for i := range elems {
+			e, err := FromGo(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = e
+		}
+		return Combine(elems...)
+	case reflect.Func:
+		if rv.IsNil() {
+			return Null{}, nil
+		} else if err := validateNative(rv.Type()); err != nil {
+			return nil, err
+		}
+		return &Function{Native: x}, nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return Null{}, nil
+		}
+		return FromGo(rv.Elem().Interface())
+	}
+	return nil, fmt.Errorf("cannot turn a value of Go type '%s' into a Gor value", rv.Type())
+}
+
+// turns a Gor value into the Go value closest to it: int, float64, string, bool, *big.Int, []any or nil
+func ToGo(v Value) any {
+	switch val := v.(type) {
+	case Int:
+		return int(val)
+	case Float:
+		return float64(val)
+	case String:
+		return string(val)
+	case Bool:
+		return bool(val)
+	case BigInt:
+		return new(big.Int).Set(val.Val)
+	case Null:
+		return nil
+	case Vector:
+		out := make([]any, len(val.Elems))
+		for i, e := range val.Elems {
+			out[i] = ToGo(e)
+		}
+		return out
+	}
+	return v
+}
+
+// converts a Gor value to a parameter of a native function,
+// the error is errParamType if it's the wrong type or says why it doesn't fit if it's too big
+func toGoParam(v Value, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() == reflect.Interface && t.NumMethod() == 0 {
+		if x := ToGo(v); x != nil {
+			return reflect.ValueOf(x), nil
+		}
+		return reflect.Zero(t), nil
+	} else if reflect.TypeOf(v).AssignableTo(t) {
+		return reflect.ValueOf(v), nil
+	}
+
+	out := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := v.(Int)
+		if !ok {
+			return out, errParamType
+		} else if out.OverflowInt(int64(i)) {
+			return out, fmt.Errorf("%s doesn't fit in the Go type '%s'", i, t)
+		}
+		out.SetInt(int64(i))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := v.(Int)
+		if !ok {
+			return out, errParamType
+		} else if i < 0 || out.OverflowUint(uint64(i)) {
+			return out, fmt.Errorf("%s doesn't fit in the Go type '%s'", i, t)
+		}
+		out.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch n := v.(type) {
+		case Float:
+			f = float64(n)
+		case Int:
+			f = float64(n)
+		default:
+			return out, errParamType
+		}
+		if out.OverflowFloat(f) {
+			return out, fmt.Errorf("%s doesn't fit in the Go type '%s'", v, t)
+		}
+		out.SetFloat(f)
+	case reflect.String:
+		s, ok := v.(String)
+		if !ok {
+			return out, errParamType
+		}
+		out.SetString(string(s))
+	case reflect.Bool:
+		b, ok := v.(Bool)
+		if !ok {
+			return out, errParamType
+		}
+		out.SetBool(bool(b))
+	case reflect.Slice:
+		// scalars are vectors of length 1, like they are in R
+		var elems []Value
+		switch vec := v.(type) {
+		case Vector:
+			elems = vec.Elems
+		case Null:
+		default:
+			elems = []Value{v}
+		}
+
+		out = reflect.MakeSlice(t, len(elems), len(elems))
+		for i, e := range elems {
+			ev, err := toGoParam(e, t.Elem())
+			if err != nil {
+				return out, err
+			}
+			out.Index(i).Set(ev)
+		}
+	case reflect.Pointer:
+		if t != bigIntType {
+			return out, errParamType
+		}
+		switch n := v.(type) {
+		case BigInt:
+			out.Set(reflect.ValueOf(new(big.Int).Set(n.Val)))
+		case Int:
+			out.Set(reflect.ValueOf(big.NewInt(int64(n))))
+		default:
+			return out, errParamType
+		}
+	default:
+		return out, errParamType
+	}
+	return out, nil
+}
+
+// the Gor name of the type a native function's parameter takes
+func paramTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Slice:
+		if paramTypeName(t.Elem()) == "" {
+			return ""
+		}
+		return "vector"
+	case reflect.Pointer:
+		if t == bigIntType {
+			return "bignum"
+		}
+	case reflect.Interface:
+		return "any"
+	}
+
+	return valueTypeNames[t]
+}
+
+// whether FromGo can turn values of the Go type into Gor values, seen stops types that contain themselves from looping forever
+func convertibleFromGo(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t.Implements(valueType) || t == bigIntType || seen[t] {
+		return true
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Pointer:
+		return convertibleFromGo(t.Elem(), seen)
+	case reflect.Interface:
+		// what's in it can only be checked once there's a value
+		return true
+	case reflect.Func:
+		return validateNative(t) == nil
+	}
+	return false
+}
+
+// checks that a Go function can be called from Gor, its parameters have to be types Gor values can be converted to, and it can return at most a value and an error
+func validateNative(fnType reflect.Type) error {
+	if fnType.Kind() != reflect.Func {
+		return fmt.Errorf("expected a function, but was given a value of Go type '%s'", fnType)
+	}
+
+	for i := range fnType.NumIn() {
+		param := fnType.In(i)
+		if fnType.IsVariadic() && i == fnType.NumIn()-1 {
+			param = param.Elem()
+		}
+		if param.Implements(valueType) || param == valueType {
+			continue
+		} else if paramTypeName(param) == "" {
+			return fmt.Errorf("parameter %d has Go type '%s', which Gor values cannot be converted to", i+1, param)
+		}
+	}
+
+	switch fnType.NumOut() {
+	case 0:
+		return nil
+	case 1:
+		if fnType.Out(0) == errorType {
+			return nil
+		}
+	case 2:
+		if fnType.Out(1) != errorType {
+			return errors.New("a function called from Gor can only return a value, an error, or a value and an error")
+		}
+	default:
+		return errors.New("a function called from Gor can only return a value, an error, or a value and an error")
+	}
+
+	if out := fnType.Out(0); !convertibleFromGo(out, map[reflect.Type]bool{fnType: true}) {
+		return fmt.Errorf("it returns Go type '%s', which cannot be turned into a Gor value", out)
+	}
+	return nil
+}
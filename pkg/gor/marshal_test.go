@@ -0,0 +1,152 @@
+package gor
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestFromGo(t *testing.T) {
+	tests := []struct {
+		x    any
+		want Value
+	}{
+		{nil, Null{}},
+		{3, Int(3)},
+		{int8(-3), Int(-3)},
+		{uint16(3), Int(3)},
+		{uint64(1 << 63), BigInt{Val: new(big.Int).SetUint64(1 << 63)}},
+		{1.5, Float(1.5)},
+		{float32(0.5), Float(0.5)},
+		{"hi", String("hi")},
+		{true, Bool(true)},
+		{big.NewInt(5), BigInt{Val: big.NewInt(5)}},
+		{(*big.Int)(nil), Null{}},
+		{[]int{1, 2}, Vector{Elems: []Value{Int(1), Int(2)}}},
+		{[2]string{"a", "b"}, Vector{Elems: []Value{String("a"), String("b")}}},
+		{[]int(nil), Null{}},
+		// c() turns everything into strings when there are any, like it does in R
+		{[]any{1, "a"}, Vector{Elems: []Value{String("1"), String("a")}}},
+		{(func() int)(nil), Null{}},
+		{(*int)(nil), Null{}},
+		{new(int), Int(0)},
+		{Int(4), Int(4)},
+	}
+
+	for _, tt := range tests {
+		got, err := FromGo(tt.x)
+		if err != nil {
+			t.Errorf("FromGo(%#v): expected no error, but got %s", tt.x, err)
+		} else if !got.Equals(tt.want) || got.TypeName() != tt.want.TypeName() {
+			t.Errorf("FromGo(%#v): expected %s, but got %s", tt.x, tt.want, got)
+		}
+	}
+
+	fn, err := FromGo(func(a int) int { return a * 2 })
+	if err != nil {
+		t.Fatalf("FromGo of a function: expected no error, but got %s", err)
+	} else if f, ok := fn.(*Function); !ok || f.Native == nil {
+		t.Errorf("FromGo of a function: expected a native function, but got %s", fn)
+	}
+
+	for _, x := range []any{map[string]int{}, struct{}{}, make(chan int), []map[int]int{{}}, func() map[string]int { return nil }} {
+		if _, err := FromGo(x); err == nil {
+			t.Errorf("FromGo(%#v): expected an error, but there wasn't one", x)
+		}
+	}
+}
+
+func TestToGo(t *testing.T) {
+	tests := []struct {
+		v    Value
+		want any
+	}{
+		{Int(3), 3},
+		{Float(1.5), 1.5},
+		{String("hi"), "hi"},
+		{Bool(false), false},
+		{BigInt{Val: big.NewInt(7)}, big.NewInt(7)},
+		{Null{}, nil},
+		{Vector{Elems: []Value{Int(1), String("a")}}, []any{1, "a"}},
+	}
+
+	for _, tt := range tests {
+		if got := ToGo(tt.v); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ToGo(%s): expected %#v, but got %#v", tt.v, tt.want, got)
+		}
+	}
+
+	// a bignum is copied, so changing it in Go doesn't change the Gor value
+	b := BigInt{Val: big.NewInt(7)}
+	ToGo(b).(*big.Int).SetInt64(8)
+	if b.Val.Int64() != 7 {
+		t.Errorf("changing the *big.Int from ToGo changed the bignum to %s", b)
+	}
+}
+
+func TestToGoParam(t *testing.T) {
+	tests := []struct {
+		v    Value
+		t    reflect.Type
+		want any
+	}{
+		{Int(3), reflect.TypeOf(0), 3},
+		{Int(255), reflect.TypeOf(uint8(0)), uint8(255)},
+		{Int(3), reflect.TypeOf(0.0), 3.0},
+		{Float(1.5), reflect.TypeOf(float32(0)), float32(1.5)},
+		{String("hi"), reflect.TypeOf(""), "hi"},
+		{Bool(true), reflect.TypeOf(false), true},
+		{Int(3), reflect.TypeOf([]int{}), []int{3}},
+		{Vector{Elems: []Value{Int(1), Int(2)}}, reflect.TypeOf([]int{}), []int{1, 2}},
+		{Null{}, reflect.TypeOf([]string{}), []string{}},
+		{Int(3), bigIntType, big.NewInt(3)},
+		{Int(3), reflect.TypeOf((*any)(nil)).Elem(), 3},
+		{Null{}, reflect.TypeOf((*any)(nil)).Elem(), nil},
+		{String("a"), valueType, String("a")},
+		{Int(3), reflect.TypeOf(Int(0)), Int(3)},
+	}
+
+	for _, tt := range tests {
+		got, err := toGoParam(tt.v, tt.t)
+		if err != nil {
+			t.Errorf("toGoParam(%s, %s): expected no error, but got %s", tt.v, tt.t, err)
+		} else if !reflect.DeepEqual(got.Interface(), tt.want) {
+			t.Errorf("toGoParam(%s, %s): expected %#v, but got %#v", tt.v, tt.t, tt.want, got.Interface())
+		}
+	}
+
+	wrongType := []struct {
+		v Value
+		t reflect.Type
+	}{
+		{String("3"), reflect.TypeOf(0)},
+		{Float(1.5), reflect.TypeOf(0)},
+		{Int(3), reflect.TypeOf("")},
+		{Vector{Elems: []Value{Int(1), String("a")}}, reflect.TypeOf([]int{})},
+		{Int(3), reflect.TypeOf(&Container{})},
+		{Int(3), reflect.TypeOf(new(int))},
+	}
+	for _, tt := range wrongType {
+		if _, err := toGoParam(tt.v, tt.t); !errors.Is(err, errParamType) {
+			t.Errorf("toGoParam(%s, %s): expected a wrong type error, but got %v", tt.v, tt.t, err)
+		}
+	}
+
+	tooBig := []struct {
+		v    Value
+		t    reflect.Type
+		want string
+	}{
+		{Int(300), reflect.TypeOf(uint8(0)), "300 doesn't fit in the Go type 'uint8'"},
+		{Int(-1), reflect.TypeOf(uint(0)), "-1 doesn't fit in the Go type 'uint'"},
+		{Int(1 << 40), reflect.TypeOf(int32(0)), "1099511627776 doesn't fit in the Go type 'int32'"},
+		{Float(1e300), reflect.TypeOf(float32(0)), "1e+300 doesn't fit in the Go type 'float32'"},
+		{Vector{Elems: []Value{Int(1), Int(300)}}, reflect.TypeOf([]uint8{}), "300 doesn't fit in the Go type 'uint8'"},
+	}
+	for _, tt := range tooBig {
+		if _, err := toGoParam(tt.v, tt.t); err == nil || errors.Is(err, errParamType) || err.Error() != tt.want {
+			t.Errorf("toGoParam(%s, %s): expected error %q, but got %v", tt.v, tt.t, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,1383 @@
+package gor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Node any
+
+type JumptoNode struct {
+	LabelIdent Token
+}
+
+type LabelNode struct {
+	Name Token
+}
+
+type ModuleImportNode struct {
+	PathIdent Token
+}
+
+type AssignableValue interface {
+	Generate(*Environment, *Interpreter) (Value, error)
+}
+
+type IfStatementNode struct {
+	Tok    Token
+	Expr   AssignableValue
+	Nodes  []Node
+	Elsifs []ElsifStatementNode
+	Else   *ElseStatementNode
+}
+
+type ElsifStatementNode struct {
+	Tok   Token
+	Expr  AssignableValue
+	Nodes []Node
+}
+
+type ElseStatementNode struct {
+	Nodes []Node
+}
+
+type WhileNode struct {
+	Tok   Token
+	Expr  AssignableValue
+	Nodes []Node
+}
+
+type ForNode struct {
+	Tok   Token
+	Var   Token
+	Iter  AssignableValue
+	Nodes []Node
+}
+
+type RepeatNode struct {
+	Tok   Token
+	Nodes []Node
+}
+
+// 'break' and 'next'
+type LoopControlNode struct {
+	Tok Token
+}
+
+type FunccallNode struct {
+	Ident Token
+	args  []AssignableValue
+}
+
+func (fn FunccallNode) GenerateArgs(env *Environment, in *Interpreter) ([]Value, error) {
+	return generateArgs(fn.args, env, in)
+}
+
+func generateArgs(args []AssignableValue, env *Environment, in *Interpreter) ([]Value, error) {
+	var out []Value
+	for _, a := range args {
+		val, err := a.Generate(env, in)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, val)
+	}
+	return out, nil
+}
+
+func (fn FunccallNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	args, err := fn.GenerateArgs(env, in)
+	if err != nil {
+		return nil, err
+	}
+	return callFunc(env, in, fn.Ident, args)
+}
+
+// a call to a value that isn't just a name, like the function another call returned
+type CallNode struct {
+	Value AssignableValue
+	Paren Token
+	args  []AssignableValue
+}
+
+func (c CallNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	val, err := c.Value.Generate(env, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// errors about the call use the name of what was called, like they do when it's called by name
+	tok := c.Paren
+	switch f := val.(type) {
+	case *Function:
+		tok.Lit = f.Name
+		if tok.Lit == "" {
+			tok.Lit = "<anonymous>"
+		}
+	case ContainerType:
+		tok.Lit = f.Name
+	default:
+		return nil, NewGorError(c.Paren, fmt.Sprintf("cannot call a value of type '%s'", val.TypeName()))
+	}
+
+	args, err := generateArgs(c.args, env, in)
+	if err != nil {
+		return nil, err
+	}
+	return callValue(val, in, tok, args)
+}
+
+type ReturnNode struct {
+	Tok   Token
+	Value AssignableValue
+}
+
+type FuncLiteralNode struct {
+	Tok    Token
+	Name   Token
+	Params []Token
+	Body   []Node
+}
+
+func (fn FuncLiteralNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	if err := in.allocAt(fn.Tok, objectSize); err != nil {
+		return nil, err
+	}
+	return &Function{Name: fn.Name.Lit, Params: fn.Params, Body: fn.Body, Closure: env, File: in.file}, nil
+}
+
+type AssignmentNode struct {
+	Ident Token
+	Value AssignableValue
+	// '<<-', which assigns to the variable outside of the current function
+	Super bool
+}
+
+type ContainerDeclNode struct {
+	Name   Token
+	Fields []Token
+}
+
+// removes a variable, a container field or vector elements
+type DeleteNode struct {
+	Tok    Token
+	Target AssignableValue
+}
+
+type FieldAccessNode struct {
+	Value AssignableValue
+	Field Token
+}
+
+func (fa FieldAccessNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	val, err := fa.Value.Generate(env, in)
+	if err != nil {
+		return nil, err
+	}
+
+	con, ok := val.(*Container)
+	if !ok {
+		return nil, NewGorError(fa.Field, fmt.Sprintf("cannot access field '%s' of a value of type '%s'", fa.Field.Lit, val.TypeName()))
+	}
+	return con.Get(fa.Field)
+}
+
+type IndexNode struct {
+	Value   AssignableValue
+	Bracket Token
+	Index   AssignableValue
+}
+
+func (idx IndexNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	val, err := idx.Value.Generate(env, in)
+	if err != nil {
+		return nil, err
+	}
+	index, err := idx.Index.Generate(env, in)
+	if err != nil {
+		return nil, err
+	}
+
+	vec, ok := val.(Vector)
+	if !ok {
+		return nil, NewGorError(idx.Bracket, fmt.Sprintf("cannot index a value of type '%s'", val.TypeName()))
+	} else if err := in.allocAt(idx.Bracket, mulSize(recycledLength(index), scalarSize)); err != nil {
+		return nil, err
+	}
+
+	res, err := vec.Index(index)
+	if err != nil {
+		return nil, NewGorError(idx.Bracket, err.Error())
+	}
+	return res, nil
+}
+
+type FieldAssignmentNode struct {
+	Ident Token
+	Path  []Token
+	Value AssignableValue
+}
+
+func removeNewlineTokens(tokens []Token) []Token {
+	var out []Token
+	for _, t := range tokens {
+		if t.Type != NEWLINE {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// whether there's nothing but newlines in the tokens, without copying them like removeNewlineTokens does
+func onlyNewlines(tokens []Token) bool {
+	for _, t := range tokens {
+		if t.Type != NEWLINE {
+			return false
+		}
+	}
+	return true
+}
+
+func checkTokenType(tokens []Token, index int, _type tokType) bool {
+	if index < len(tokens) {
+		return tokens[index].Istype(_type)
+	}
+	return false
+}
+
+func collectUntilToken(tokens []Token, _type tokType, oposing_type tokType) ([]Token, bool) {
+	nest := 0
+	for i, t := range tokens {
+		if t.Istype(_type) {
+			if nest > 0 {
+				nest--
+				continue
+			}
+			return tokens[:i], true
+		} else if t.Istype(oposing_type) && oposing_type != NULLTOKEN {
+			nest++
+		}
+	}
+	return tokens, false
+}
+
+// what parsing the tokens of a file needs to keep track of
+type parser struct {
+	ctx context.Context
+	// how far after each '{' its '}' is, so blocks can be skipped over without going through every token in them
+	blockEnds map[*Token]int
+	// how many blocks and expressions the parser is inside of
+	depth int
+	// how many statements and values have been parsed, ctx is checked every so often
+	parsed int
+}
+
+// the most blocks, parentheses and operators that code can be nested inside of, more would run Go out of stack
+const maxNestingDepth = 10_000
+
+func newParser(ctx context.Context, tokens []Token) *parser {
+	p := &parser{ctx: ctx, blockEnds: make(map[*Token]int)}
+
+	var opened []int
+	for i, t := range tokens {
+		if t.Istype(LBRACE) {
+			opened = append(opened, i)
+		} else if t.Istype(RBRACE) && len(opened) > 0 {
+			open := opened[len(opened)-1]
+			opened = opened[:len(opened)-1]
+			p.blockEnds[&tokens[open]] = i - open
+		}
+	}
+	return p
+}
+
+// called when the parser goes into a block or expression at tok, leave has to be called once it's out of it
+func (p *parser) enter(tok Token) error {
+	if p.depth >= maxNestingDepth {
+		return NewGorError(tok, fmt.Sprintf("the code is nested more than %d levels deep", maxNestingDepth))
+	}
+	p.depth++
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// stops parsing if ctx was cancelled, it's only checked every so often as it can be slow
+func (p *parser) checkStopped(tok Token) error {
+	p.parsed++
+	if p.parsed%cancelCheckInterval == 0 && p.ctx.Err() != nil {
+		return stoppedError(tok, p.ctx.Err())
+	}
+	return nil
+}
+
+// collects the tokens of the block that tokens[open] starts, not including its braces
+func (p *parser) collectBlock(tokens []Token, open int) ([]Token, bool) {
+	if end, ok := p.blockEnds[&tokens[open]]; ok && open+end < len(tokens) {
+		return tokens[open+1 : open+end], true
+	}
+	// the tokens were copied from the ones the parser was made with, or the block isn't closed
+	return collectUntilToken(tokens[open+1:], RBRACE, LBRACE)
+}
+
+// collects tokens until a semicolon that isn't inside of a pair of braces
+func (p *parser) collectUntilStatementEnd(tokens []Token) ([]Token, bool) {
+	nest := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.Istype(LBRACE) {
+			if end, ok := p.blockEnds[&tokens[i]]; ok && nest == 0 && i+end < len(tokens) {
+				i += end
+				continue
+			}
+			nest++
+		} else if t.Istype(RBRACE) {
+			nest--
+		} else if t.Istype(SEMICOLON) && nest == 0 {
+			return tokens[:i], true
+		}
+	}
+	return tokens, false
+}
+
+// parses a function literal starting at the 'func' keyword, and returns how many tokens it used
+func (p *parser) parseFuncLiteral(tokens []Token, named bool) (FuncLiteralNode, int, error) {
+	fn := FuncLiteralNode{Tok: tokens[0]}
+	idx := 1
+
+	if named {
+		if !checkTokenType(tokens, idx, IDENT) {
+			return FuncLiteralNode{}, 0, NewGorError(tokens[idx-1], "expected function name")
+		}
+		fn.Name = tokens[idx]
+		idx++
+	}
+
+	if !checkTokenType(tokens, idx, LPAREN) {
+		return FuncLiteralNode{}, 0, NewGorError(tokens[idx-1], "expected '('")
+	}
+	idx++
+
+	paramToks, ok := collectUntilToken(tokens[idx:], RPAREN, LPAREN)
+	if !ok {
+		return FuncLiteralNode{}, 0, NewGorError(tokens[idx-1], "expected ')'")
+	}
+	idx += len(paramToks) + 1
+
+	paramToks = removeNewlineTokens(paramToks)
+	for i, t := range paramToks {
+		if i%2 == 1 {
+			if !t.Istype(COMMA) {
+				return FuncLiteralNode{}, 0, NewGorError(t, fmt.Sprintf("expected ',', but found '%s' instead", t.Lit))
+			}
+			continue
+		} else if !t.Istype(IDENT) {
+			return FuncLiteralNode{}, 0, NewGorError(t, fmt.Sprintf("expected parameter name, but found '%s' instead", t.Lit))
+		}
+
+		for _, param := range fn.Params {
+			if param.Lit == t.Lit {
+				return FuncLiteralNode{}, 0, NewGorError(t, fmt.Sprintf("duplicate parameter '%s'", t.Lit))
+			}
+		}
+		fn.Params = append(fn.Params, t)
+	}
+	if len(paramToks) > 0 && paramToks[len(paramToks)-1].Istype(COMMA) {
+		return FuncLiteralNode{}, 0, NewGorError(paramToks[len(paramToks)-1], "expected parameter name after ','")
+	}
+
+	for checkTokenType(tokens, idx, NEWLINE) {
+		idx++
+	}
+	if !checkTokenType(tokens, idx, LBRACE) {
+		return FuncLiteralNode{}, 0, NewGorError(tokens[idx-1], "expected '{'")
+	}
+	idx++
+
+	bodyToks, ok := p.collectBlock(tokens, idx-1)
+	if !ok {
+		return FuncLiteralNode{}, 0, NewGorError(tokens[idx-1], "expected '}'")
+	}
+
+	body, err := p.parseBody(tokens[idx-1], bodyToks)
+	if err != nil {
+		return FuncLiteralNode{}, 0, err
+	}
+	fn.Body = body
+
+	return fn, idx + len(bodyToks) + 1, nil
+}
+
+// collects the header tokens between a keyword and the '{' of its block, then parses the block and returns how many tokens were used
+func (p *parser) parseBlock(keyword Token, tokens []Token) ([]Token, []Node, int, error) {
+	headerToks, ok := collectUntilToken(tokens, LBRACE, NULLTOKEN)
+	if !ok {
+		return nil, nil, 0, NewGorError(keyword, fmt.Sprintf("expected '{' after '%s'", keyword.Lit))
+	}
+	idx := len(headerToks) + 1
+
+	bodyToks, ok := p.collectBlock(tokens, idx-1)
+	if !ok {
+		return nil, nil, 0, NewGorError(tokens[idx-1], "expected '}'")
+	}
+
+	body, err := p.parseBody(tokens[idx-1], bodyToks)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return removeNewlineTokens(headerToks), body, idx + len(bodyToks) + 1, nil
+}
+
+func (p *parser) parseForHeader(forTok Token, headerToks []Token) (Token, AssignableValue, error) {
+	if len(headerToks) >= 2 && headerToks[0].Istype(LPAREN) && headerToks[len(headerToks)-1].Istype(RPAREN) {
+		headerToks = headerToks[1 : len(headerToks)-1]
+	}
+
+	if len(headerToks) == 0 || !headerToks[0].Istype(IDENT) {
+		return Token{}, nil, NewGorError(forTok, "expected loop variable after 'for'")
+	} else if len(headerToks) < 2 || !headerToks[1].Istype(KEYWORD) || headerToks[1].Lit != "in" {
+		return Token{}, nil, NewGorError(headerToks[0], "expected 'in' after loop variable")
+	} else if len(headerToks) == 2 {
+		return Token{}, nil, NewGorError(headerToks[1], "expected value to loop over after 'in'")
+	}
+
+	iter, err := p.generateExpressionNodeFromTokens(headerToks[1], headerToks[2:])
+	if err != nil {
+		return Token{}, nil, err
+	}
+	return headerToks[0], iter, nil
+}
+
+// parses the fields of a container declaration, which can be separated by semicolons, commas or newlines
+func parseContainerFields(tokens []Token) ([]Token, error) {
+	var fields []Token
+	expectField := true
+	for _, t := range tokens {
+		if t.Istype(NEWLINE) || t.Istype(SEMICOLON) || t.Istype(COMMA) || t.Istype(COMMENT) {
+			expectField = true
+			continue
+		} else if !t.Istype(IDENT) {
+			return []Token{}, NewGorError(t, fmt.Sprintf("expected field name, but found '%s' instead", t.Lit))
+		} else if !expectField {
+			return []Token{}, NewGorError(t, fmt.Sprintf("expected ';' before field '%s'", t.Lit))
+		}
+
+		for _, f := range fields {
+			if f.Lit == t.Lit {
+				return []Token{}, NewGorError(t, fmt.Sprintf("duplicate field '%s'", t.Lit))
+			}
+		}
+		fields = append(fields, t)
+		expectField = false
+	}
+	return fields, nil
+}
+
+// how tightly each binary operator binds, higher binds tighter
+var binaryPrecedence = map[tokType]int{
+	OR:             1,
+	AND:            2,
+	EQUALS:         4,
+	NOT_EQUALS:     4,
+	LESSER_THAN:    5,
+	GREATER_THAN:   5,
+	LESSER_EQUALS:  5,
+	GREATER_EQUALS: 5,
+	PLUS:           6,
+	HYPHEN:         6,
+	ASTERISK:       7,
+	FORWARD_SLASH:  7,
+	PERCENT_SIGN:   7,
+	COLON:          8,
+}
+
+// '!' takes everything after it up to the next '&&' or '||', so '!x == 2' is '!(x == 2)' like it is in R
+const notPrecedence = 3
+
+// a precedence climbing parser for the tokens of a single expression
+type expressionParser struct {
+	parser *parser
+	tokens []Token
+	idx    int
+}
+
+// newlines and comments can be anywhere in an expression, so they're skipped over
+func skippable(t Token) bool {
+	return t.Istype(NEWLINE) || t.Istype(COMMENT)
+}
+
+func (p *expressionParser) peek() (Token, bool) {
+	for p.idx < len(p.tokens) && skippable(p.tokens[p.idx]) {
+		p.idx++
+	}
+	if p.idx < len(p.tokens) {
+		return p.tokens[p.idx], true
+	}
+	return Token{}, false
+}
+
+func (p *expressionParser) endError(msg string) error {
+	last := len(p.tokens) - 1
+	for last > 0 && skippable(p.tokens[last]) {
+		last--
+	}
+	return NewGorError(p.tokens[last], msg)
+}
+
+func (p *expressionParser) expect(_type tokType, lit string) (Token, error) {
+	t, ok := p.peek()
+	if !ok {
+		return Token{}, p.endError(fmt.Sprintf("expected '%s', but the expression ended", lit))
+	} else if !t.Istype(_type) {
+		return Token{}, NewGorError(t, fmt.Sprintf("expected '%s', but found '%s' instead", lit, t.Lit))
+	}
+	p.idx++
+	return t, nil
+}
+
+func (p *expressionParser) parseExpression(minPrecedence int) (AssignableValue, error) {
+	depth := p.parser.depth
+	defer func() {
+		p.parser.depth = depth
+	}()
+	if t, ok := p.peek(); ok {
+		if err := p.parser.enter(t); err != nil {
+			return nil, err
+		}
+	}
+
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.peek()
+		if !ok {
+			break
+		}
+		precedence, isOp := binaryPrecedence[op.Type]
+		if !isOp || precedence < minPrecedence {
+			break
+		}
+		p.idx++
+		// each operator puts what's before it a level deeper, which is as deep to go through as parentheses are
+		if err := p.parser.enter(op); err != nil {
+			return nil, err
+		}
+
+		// parsing the right side with a higher minimum makes operators of the same precedence left associative
+		right, err := p.parseExpression(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Istype(AND) || op.Istype(OR) {
+			left = LogicalNode{Left: left, Operand: op, Right: right}
+		} else {
+			left = ExpressionNode{Left: left, Operand: op, Right: right}
+		}
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (AssignableValue, error) {
+	t, ok := p.peek()
+	if ok && t.Istype(NOT) {
+		p.idx++
+		operand, err := p.parseExpression(notPrecedence)
+		if err != nil {
+			return nil, err
+		}
+		return UnaryNode{Operand: t, Value: operand}, nil
+	} else if ok && t.Istype(HYPHEN) {
+		p.idx++
+		if err := p.parser.enter(t); err != nil {
+			return nil, err
+		}
+		defer p.parser.leave()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return UnaryNode{Operand: t, Value: operand}, nil
+	}
+
+	return p.parsePostfix()
+}
+
+func (p *expressionParser) parsePostfix() (AssignableValue, error) {
+	val, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t, ok := p.peek()
+		if !ok {
+			return val, nil
+		} else if t.Istype(DOT) || t.Istype(LBRACKET) || t.Istype(LPAREN) {
+			// parseExpression puts the depth back once it's done with the value
+			if err := p.parser.enter(t); err != nil {
+				return nil, err
+			}
+		}
+
+		switch t.Type {
+		case DOT:
+			p.idx++
+			field, err := p.expect(IDENT, "field name")
+			if err != nil {
+				return nil, err
+			}
+			val = FieldAccessNode{Value: val, Field: field}
+		case LBRACKET:
+			p.idx++
+			index, err := p.parseExpression(1)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(RBRACKET, "]"); err != nil {
+				return nil, err
+			}
+			val = IndexNode{Value: val, Bracket: t, Index: index}
+		case LPAREN:
+			args, err := p.parseArgs("call")
+			if err != nil {
+				return nil, err
+			}
+			val = CallNode{Value: val, Paren: t, args: args}
+		default:
+			return val, nil
+		}
+	}
+}
+
+func (p *expressionParser) parsePrimary() (AssignableValue, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, p.endError("expected value, but the expression ended")
+	} else if err := p.parser.checkStopped(t); err != nil {
+		return nil, err
+	}
+
+	switch t.Type {
+	case NUMBER, STRING:
+		p.idx++
+		return ValueNode{Val: t}, nil
+	case IDENT:
+		p.idx++
+		if next, ok := p.peek(); ok && next.Istype(LPAREN) {
+			return p.parseCall(t)
+		}
+		return ValueNode{Val: t}, nil
+	case LPAREN:
+		p.idx++
+		inner, err := p.parseExpression(1)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(RPAREN, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case KEYWORD:
+		if t.Lit == "true" || t.Lit == "false" {
+			p.idx++
+			return ValueNode{Val: t}, nil
+		} else if t.Lit == "func" {
+			fn, used, err := p.parser.parseFuncLiteral(p.tokens[p.idx:], false)
+			if err != nil {
+				return nil, err
+			}
+			p.idx += used
+			return fn, nil
+		}
+	}
+
+	return nil, NewGorError(t, fmt.Sprintf("unexpected '%s' in expression", t.Lit))
+}
+
+func (p *expressionParser) parseCall(ident Token) (AssignableValue, error) {
+	args, err := p.parseArgs(fmt.Sprintf("call to '%s'", ident.Lit))
+	if err != nil {
+		return nil, err
+	}
+	return FunccallNode{Ident: ident, args: args}, nil
+}
+
+// parses the arguments of a call starting from its '(', what is what the error says the ')' closes
+func (p *expressionParser) parseArgs(what string) ([]AssignableValue, error) {
+	p.idx++
+
+	var args []AssignableValue
+	if t, ok := p.peek(); ok && t.Istype(RPAREN) {
+		p.idx++
+		return args, nil
+	}
+
+	for {
+		arg, err := p.parseExpression(1)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		t, ok := p.peek()
+		if !ok {
+			return nil, p.endError(fmt.Sprintf("expected ')' to close the %s", what))
+		}
+		p.idx++
+		if t.Istype(RPAREN) {
+			return args, nil
+		} else if !t.Istype(COMMA) {
+			return nil, NewGorError(t, fmt.Sprintf("expected ',' or ')', but found '%s' instead", t.Lit))
+		}
+	}
+}
+
+// parses the tokens as an expression, tok is what the expression comes after and is where the error goes if there isn't one
+func (p *parser) generateExpressionNodeFromTokens(tok Token, tokens []Token) (AssignableValue, error) {
+	exprParser := expressionParser{parser: p, tokens: tokens}
+	if _, ok := exprParser.peek(); !ok {
+		return nil, NewGorError(tok, "expected expression")
+	}
+
+	expr, err := exprParser.parseExpression(1)
+	if err != nil {
+		return nil, err
+	} else if t, ok := exprParser.peek(); ok {
+		return nil, NewGorError(t, fmt.Sprintf("unexpected '%s' in expression", t.Lit))
+	}
+	return expr, nil
+}
+
+// '&&' and '||', which only generate their right side if the left side didn't already decide the result
+type LogicalNode struct {
+	Left    AssignableValue
+	Operand Token
+	Right   AssignableValue
+}
+
+func (l LogicalNode) generateSide(side string, value AssignableValue, env *Environment, in *Interpreter) (Bool, error) {
+	val, err := value.Generate(env, in)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := val.(Bool)
+	if !ok {
+		return false, NewGorError(l.Operand, fmt.Sprintf("'%s' expects values of type 'bool', but the %s side was of type '%s'", l.Operand.Lit, side, val.TypeName()))
+	}
+	return b, nil
+}
+
+func (l LogicalNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	left, err := l.generateSide("left", l.Left, env, in)
+	if err != nil {
+		return nil, err
+	} else if l.Operand.Istype(AND) && !bool(left) || l.Operand.Istype(OR) && bool(left) {
+		return left, nil
+	}
+	return l.generateSide("right", l.Right, env, in)
+}
+
+type UnaryNode struct {
+	Operand Token
+	Value   AssignableValue
+}
+
+func (u UnaryNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	val, err := u.Value.Generate(env, in)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Operand.Type {
+	case HYPHEN:
+		switch val.(type) {
+		case Vector, BigInt:
+			if err := in.allocAt(u.Operand, sizeOf(val)); err != nil {
+				return nil, err
+			}
+		}
+		if n, ok := val.(Negatable); ok {
+			res, err := n.Negate()
+			if err == nil {
+				return res, nil
+			} else if !errors.Is(err, ErrUnsupportedOperation) {
+				return nil, &GorError{Tok: u.Operand, Msg: err.Error(), Err: err}
+			}
+		}
+	case NOT:
+		if b, ok := val.(Bool); ok {
+			return !b, nil
+		}
+	}
+	return nil, NewGorError(u.Operand, fmt.Sprintf("cannot use '%s' on a value of type '%s'", u.Operand.Lit, val.TypeName()))
+}
+
+type ExpressionNode struct {
+	Left    AssignableValue
+	Operand Token
+	Right   AssignableValue
+}
+
+func (expr ExpressionNode) operationError(left, right Value, err error) error {
+	if errors.Is(err, ErrUnsupportedOperation) {
+		return NewGorError(expr.Operand, fmt.Sprintf("cannot use '%s' on values of type '%s' and '%s'", expr.Operand.Lit, left.TypeName(), right.TypeName()))
+	}
+	return &GorError{Tok: expr.Operand, Msg: err.Error(), Err: err}
+}
+
+func (expr ExpressionNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	left, err := expr.Left.Generate(env, in)
+	if err != nil {
+		return nil, err
+	}
+	right, err := expr.Right.Generate(env, in)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.Operand.Type {
+	case EQUALS, NOT_EQUALS, GREATER_THAN, LESSER_THAN, GREATER_EQUALS, LESSER_EQUALS:
+		if err := in.alloc(resultSize(expr.Operand.Type, left, right)); err != nil {
+			return nil, expr.operationError(left, right, err)
+		}
+		res, err := CompareValues(expr.Operand.Type, left, right)
+		if err != nil {
+			return nil, expr.operationError(left, right, err)
+		}
+		return res, nil
+	case COLON:
+		from, to, length, err := rangeArgs(left, right)
+		if err != nil {
+			return nil, expr.operationError(left, right, err)
+		}
+		if err := in.alloc(mulSize(length, scalarSize)); err != nil {
+			return nil, expr.operationError(left, right, err)
+		}
+
+		res, err := in.buildVector(length, func(i int) Value {
+			return rangeElem(from, to, i)
+		})
+		if err != nil {
+			return nil, expr.operationError(left, right, err)
+		}
+		return res, nil
+	}
+
+	if err := in.alloc(resultSize(expr.Operand.Type, left, right)); err != nil {
+		return nil, expr.operationError(left, right, err)
+	}
+
+	// scalars on the left of a vector get recycled like a vector of length 1
+	if _, ok := right.(Vector); ok {
+		if _, ok := left.(Vector); !ok {
+			left = Vector{Elems: []Value{left}}
+		}
+	}
+
+	arith, ok := left.(arithmetic)
+	if !ok {
+		return nil, expr.operationError(left, right, ErrUnsupportedOperation)
+	}
+
+	res, err := arith.Arithmetic(expr.Operand.Type, right)
+	if err != nil {
+		return nil, expr.operationError(left, right, err)
+	}
+	return res, nil
+}
+
+// turns a number literal from the lexer into an Int or a Float
+func ParseNumber(lit string) (Value, error) {
+	digits := strings.TrimSuffix(strings.ReplaceAll(lit, "_", ""), "L")
+	isHex := strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X")
+
+	if !isHex && strings.ContainsAny(digits, ".eE") {
+		res, err := strconv.ParseFloat(digits, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, fmt.Errorf("number '%s' is out of range for a float", lit)
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", lit)
+		}
+		return Float(res), nil
+	}
+
+	res, err := strconv.ParseInt(digits, intBase(digits), strconv.IntSize)
+	if errors.Is(err, strconv.ErrRange) {
+		return nil, fmt.Errorf("number '%s' is too big for an int, use bignum(\"%s\") instead", lit, digits)
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid number '%s'", lit)
+	}
+	return Int(res), nil
+}
+
+type ValueNode struct {
+	Val Token
+}
+
+func (v ValueNode) Generate(env *Environment, in *Interpreter) (Value, error) {
+	switch v.Val.Type {
+	case STRING:
+		return String(v.Val.Lit), nil
+	case NUMBER:
+		num, err := ParseNumber(v.Val.Lit)
+		if err != nil {
+			return nil, NewGorError(v.Val, err.Error())
+		}
+		return num, nil
+	case KEYWORD:
+		if v.Val.Lit == "true" || v.Val.Lit == "false" {
+			return Bool(v.Val.Lit == "true"), nil
+		}
+	case IDENT:
+		if val, ok := env.Get(v.Val.Lit); ok {
+			return val, nil
+		} else if fn, ok := in.funcs[v.Val.Lit]; ok {
+			return fn, nil
+		}
+		return nil, NewGorError(v.Val, fmt.Sprintf("unknown variable '%s'", v.Val.Lit))
+	}
+	return nil, NewGorError(v.Val, fmt.Sprintf("'%s' is not a value", v.Val.Lit))
+}
+
+// whether the expression names something which 'delete' can remove
+func isDeletable(target AssignableValue) bool {
+	switch t := target.(type) {
+	case ValueNode:
+		return t.Val.Type == IDENT
+	case FieldAccessNode:
+		return true
+	case IndexNode:
+		if v, ok := t.Value.(ValueNode); ok {
+			return v.Val.Type == IDENT
+		}
+		_, ok := t.Value.(FieldAccessNode)
+		return ok
+	}
+	return false
+}
+
+// the arithmetic operator each compound assignment uses
+var compoundOperators = map[tokType]tokType{
+	PLUS_ASSIGN:   PLUS,
+	MINUS_ASSIGN:  HYPHEN,
+	TIMES_ASSIGN:  ASTERISK,
+	DIVIDE_ASSIGN: FORWARD_SLASH,
+}
+
+// turns 'x += value' into 'x <- x + value'
+func compoundValue(target AssignableValue, assignTok Token, value AssignableValue) AssignableValue {
+	opTok := assignTok
+	opTok.Type = compoundOperators[assignTok.Type]
+	opTok.Lit = strings.TrimSuffix(assignTok.Lit, "=")
+	return ExpressionNode{Left: target, Operand: opTok, Right: value}
+}
+
+// finds the statement at the start of the tokens if it's a right assignment like 'value -> x;', returning the index of the '->' or -1 if it isn't one
+func (p *parser) findRightAssign(tokens []Token) ([]Token, int) {
+	if tokens[0].Istype(COLON) {
+		return nil, -1
+	} else if tokens[0].Istype(KEYWORD) {
+		// only keywords that can start an expression, and named functions don't end with a ';'
+		isLiteral := tokens[0].Lit == "true" || tokens[0].Lit == "false"
+		isFuncLiteral := tokens[0].Lit == "func" && checkTokenType(tokens, 1, LPAREN)
+		if !isLiteral && !isFuncLiteral {
+			return nil, -1
+		}
+	}
+
+	stmtToks, ok := p.collectUntilStatementEnd(tokens)
+	if !ok {
+		return nil, -1
+	}
+
+	nest := 0
+	for i := 0; i < len(stmtToks); i++ {
+		t := stmtToks[i]
+		if t.Istype(LBRACE) {
+			if end, ok := p.blockEnds[&stmtToks[i]]; ok && nest == 0 && i+end < len(stmtToks) {
+				i += end
+				continue
+			}
+			nest++
+		} else if t.Istype(RBRACE) {
+			nest--
+		} else if nest > 0 {
+			continue
+		} else if t.Istype(RIGHT_ASSIGN) {
+			return stmtToks, i
+		} else if _, isCompound := compoundOperators[t.Type]; isCompound || t.Istype(ASSIGN) || t.Istype(SUPER_ASSIGN) {
+			// a normal assignment, which might not need a ';' if it's a function
+			return nil, -1
+		}
+	}
+	return nil, -1
+}
+
+func Parse(tokens []Token) ([]Node, error) {
+	return ParseContext(context.Background(), tokens)
+}
+
+// like Parse, but stops once ctx is cancelled
+func ParseContext(ctx context.Context, tokens []Token) ([]Node, error) {
+	if len(tokens) == 0 {
+		return []Node{}, nil
+	}
+	if tokens[len(tokens)-1].Type == EOF {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return newParser(ctx, tokens).parse(tokens)
+}
+
+// parses the tokens of a block, open is its '{'
+func (p *parser) parseBody(open Token, tokens []Token) ([]Node, error) {
+	if err := p.enter(open); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	return p.parse(tokens)
+}
+
+func (p *parser) parse(tokens []Token) ([]Node, error) {
+	var nodes []Node
+	idx := 0
+	for idx < len(tokens) {
+		if err := p.checkStopped(tokens[idx]); err != nil {
+			return []Node{}, err
+		}
+
+		if tokens[idx].Istype(NEWLINE) || tokens[idx].Istype(COMMENT) {
+			idx++
+		} else if stmtToks, arrow := p.findRightAssign(tokens[idx:]); arrow != -1 {
+			target := removeNewlineTokens(stmtToks[arrow+1:])
+			if len(target) != 1 || !target[0].Istype(IDENT) {
+				return []Node{}, NewGorError(stmtToks[arrow], "expected a variable name after '->'")
+			} else if arrow == 0 {
+				return []Node{}, NewGorError(stmtToks[arrow], "expected expression before '->'")
+			}
+
+			gen, err := p.generateExpressionNodeFromTokens(stmtToks[arrow], stmtToks[:arrow])
+			if err != nil {
+				return []Node{}, err
+			}
+			nodes = append(nodes, AssignmentNode{Ident: target[0], Value: gen})
+			idx += len(stmtToks) + 1
+		} else if tokens[idx].Istype(IDENT) {
+			ident := tokens[idx]
+			idx++
+
+			if idx >= len(tokens) {
+				return []Node{}, NewGorError(ident, "expected assign glyph or function call")
+			}
+
+			var fieldPath []Token
+			for checkTokenType(tokens, idx, DOT) {
+				if !checkTokenType(tokens, idx+1, IDENT) {
+					return []Node{}, NewGorError(tokens[idx], "expected field name after '.'")
+				}
+				fieldPath = append(fieldPath, tokens[idx+1])
+				idx += 2
+			}
+
+			if idx >= len(tokens) {
+				return []Node{}, NewGorError(tokens[idx-1], "expected assign glyph")
+			}
+
+			if len(fieldPath) > 0 {
+				assignTok := tokens[idx]
+				_, isCompound := compoundOperators[assignTok.Type]
+				if !assignTok.Istype(ASSIGN) && !isCompound {
+					return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected assign glyph, but found '%s' instead", string(tokens[idx].Lit)))
+				}
+				idx++
+
+				exprToks, ok := p.collectUntilStatementEnd(tokens[idx:])
+				if onlyNewlines(exprToks) {
+					return []Node{}, NewGorError(tokens[idx-1], "expected expression")
+				} else if !ok {
+					return []Node{}, NewGorError(tokens[idx-1], "expected ';'")
+				}
+				gen, err := p.generateExpressionNodeFromTokens(assignTok, exprToks)
+				if err != nil {
+					return []Node{}, err
+				} else if isCompound {
+					var field AssignableValue = ValueNode{Val: ident}
+					for _, f := range fieldPath {
+						field = FieldAccessNode{Value: field, Field: f}
+					}
+					gen = compoundValue(field, assignTok, gen)
+				}
+				nodes = append(nodes, FieldAssignmentNode{Ident: ident, Path: fieldPath, Value: gen})
+				idx += len(exprToks) + 1
+			} else if tokens[idx].Istype(LPAREN) {
+				callToks, _ := p.collectUntilStatementEnd(tokens[idx-1:])
+				gen, err := p.generateExpressionNodeFromTokens(ident, callToks)
+				if err != nil {
+					return []Node{}, err
+				}
+				switch call := gen.(type) {
+				case FunccallNode:
+					nodes = append(nodes, call)
+				case CallNode:
+					nodes = append(nodes, call)
+				default:
+					return []Node{}, NewGorError(ident, "expected ';' after function call")
+				}
+
+				if !checkTokenType(tokens, idx-1+len(callToks), SEMICOLON) {
+					return []Node{}, NewGorError(ident, "expected ';'")
+				}
+				idx += len(callToks)
+			} else if _, isCompound := compoundOperators[tokens[idx].Type]; isCompound || tokens[idx].Istype(ASSIGN) || tokens[idx].Istype(SUPER_ASSIGN) {
+				assignTok := tokens[idx]
+				super := assignTok.Istype(SUPER_ASSIGN)
+				idx++
+
+				if idx >= len(tokens) {
+					return []Node{}, NewGorError(assignTok, "expected expression")
+				}
+				if !isCompound && checkTokenType(tokens, idx, KEYWORD) && tokens[idx].Lit == "func" {
+					fn, used, err := p.parseFuncLiteral(tokens[idx:], false)
+					if err != nil {
+						return []Node{}, err
+					}
+					nodes = append(nodes, AssignmentNode{Ident: ident, Value: fn, Super: super})
+					idx += used
+					if checkTokenType(tokens, idx, SEMICOLON) {
+						idx++
+					}
+					continue
+				}
+
+				//fmt.Println(tokens)
+				exprToks, _ := p.collectUntilStatementEnd(tokens[idx:])
+				if len(exprToks) == 0 {
+					return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected expression, but found '%s' instead", string(tokens[idx].Lit)))
+				}
+				gen, err := p.generateExpressionNodeFromTokens(assignTok, exprToks)
+				if err != nil {
+					return []Node{}, err
+				} else if isCompound {
+					gen = compoundValue(ValueNode{Val: ident}, assignTok, gen)
+				}
+				nodes = append(nodes, AssignmentNode{Ident: ident, Value: gen, Super: super})
+
+				if !checkTokenType(tokens, idx+len(exprToks), SEMICOLON) {
+					return []Node{}, NewGorError(tokens[idx], "expected ';'")
+				}
+				idx += len(exprToks) + 1
+			} else {
+				return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected assign glyph, but found '%s' instead", string(tokens[idx].Lit)))
+			}
+		} else if tokens[idx].Istype(COLON) {
+			if idx+1 < len(tokens) {
+				if idx+2 < len(tokens) {
+					if !tokens[idx+2].Istype(COLON) {
+						return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected colon(':'), but found '%s' instead", string(tokens[idx].Lit)))
+					}
+					nodes = append(nodes, LabelNode{Name: tokens[idx+1]})
+					idx += 3
+				} else {
+					return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected colon(':'), but found '%s' instead", string(tokens[idx].Lit)))
+				}
+			} else {
+				return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected identifier, but found '%s' instead", string(tokens[idx].Lit)))
+			}
+		} else if tokens[idx].Istype(KEYWORD) {
+			switch tokens[idx].Lit {
+			case "if", "elsif", "else":
+				orig := tokens[idx]
+				idx++
+				ifExprToks, ok := collectUntilToken(tokens[idx:], LBRACE, NULLTOKEN)
+				if !ok {
+					return []Node{}, NewGorError(tokens[idx-1], "expected '{'")
+				}
+				idx += len(ifExprToks) + 1
+
+				if orig.Lit == "else" && !onlyNewlines(ifExprToks) {
+					return []Node{}, NewGorError(ifExprToks[0], "expected '{' after 'else'")
+				} else if orig.Lit != "else" && onlyNewlines(ifExprToks) {
+					return []Node{}, NewGorError(orig, fmt.Sprintf("expected condition after '%s'", orig.Lit))
+				}
+
+				ifBodyToks, ok := p.collectBlock(tokens, idx-1)
+				if !ok {
+					return []Node{}, NewGorError(tokens[idx-1], "expected '}'")
+				}
+				//fmt.Println("body", ifBodyToks)
+
+				ifBodyNodes, ifParseErr := p.parseBody(tokens[idx-1], ifBodyToks)
+				if ifParseErr != nil {
+					return []Node{}, ifParseErr
+				}
+				idx += len(ifBodyToks) + 1
+
+				if orig.Lit == "if" {
+					gen, err := p.generateExpressionNodeFromTokens(orig, ifExprToks)
+					if err != nil {
+						return []Node{}, err
+					}
+					nodes = append(nodes, IfStatementNode{Tok: orig, Expr: gen, Nodes: ifBodyNodes})
+					continue
+				}
+
+				// elsif and else attach to the if statement right before them
+				var prevIf IfStatementNode
+				if len(nodes) > 0 {
+					prevIf, ok = nodes[len(nodes)-1].(IfStatementNode)
+				}
+				if len(nodes) == 0 || !ok {
+					return []Node{}, NewGorError(orig, fmt.Sprintf("'%s' without a preceding 'if'", orig.Lit))
+				} else if prevIf.Else != nil {
+					return []Node{}, NewGorError(orig, fmt.Sprintf("'%s' after 'else'", orig.Lit))
+				}
+
+				if orig.Lit == "elsif" {
+					gen, err := p.generateExpressionNodeFromTokens(orig, ifExprToks)
+					if err != nil {
+						return []Node{}, err
+					}
+					prevIf.Elsifs = append(prevIf.Elsifs, ElsifStatementNode{Tok: orig, Expr: gen, Nodes: ifBodyNodes})
+				} else {
+					prevIf.Else = &ElseStatementNode{Nodes: ifBodyNodes}
+				}
+				nodes[len(nodes)-1] = prevIf
+			case "while", "for", "repeat":
+				loopTok := tokens[idx]
+				headerToks, body, used, err := p.parseBlock(loopTok, tokens[idx+1:])
+				if err != nil {
+					return []Node{}, err
+				}
+				idx += used + 1
+
+				switch loopTok.Lit {
+				case "while":
+					if len(headerToks) == 0 {
+						return []Node{}, NewGorError(loopTok, "expected condition after 'while'")
+					}
+					gen, err := p.generateExpressionNodeFromTokens(loopTok, headerToks)
+					if err != nil {
+						return []Node{}, err
+					}
+					nodes = append(nodes, WhileNode{Tok: loopTok, Expr: gen, Nodes: body})
+				case "for":
+					loopVar, iter, err := p.parseForHeader(loopTok, headerToks)
+					if err != nil {
+						return []Node{}, err
+					}
+					nodes = append(nodes, ForNode{Tok: loopTok, Var: loopVar, Iter: iter, Nodes: body})
+				default:
+					if len(headerToks) > 0 {
+						return []Node{}, NewGorError(headerToks[0], "expected '{' after 'repeat'")
+					}
+					nodes = append(nodes, RepeatNode{Tok: loopTok, Nodes: body})
+				}
+			case "delete":
+				delTok := tokens[idx]
+				idx++
+				targetToks, ok := p.collectUntilStatementEnd(tokens[idx:])
+				if !ok {
+					return []Node{}, NewGorError(delTok, "expected ';'")
+				} else if onlyNewlines(targetToks) {
+					return []Node{}, NewGorError(delTok, "expected a variable, field or index to delete")
+				}
+
+				target, err := p.generateExpressionNodeFromTokens(delTok, targetToks)
+				if err != nil {
+					return []Node{}, err
+				} else if !isDeletable(target) {
+					return []Node{}, NewGorError(delTok, "can only delete variables, container fields and vector elements")
+				}
+				nodes = append(nodes, DeleteNode{Tok: delTok, Target: target})
+				idx += len(targetToks) + 1
+			case "break", "next":
+				if !checkTokenType(tokens, idx+1, SEMICOLON) {
+					return []Node{}, NewGorError(tokens[idx], "expected ';'")
+				}
+				nodes = append(nodes, LoopControlNode{Tok: tokens[idx]})
+				idx += 2
+			case "jumpto":
+				if checkTokenType(tokens, idx+1, IDENT) {
+					if !checkTokenType(tokens, idx+2, SEMICOLON) {
+						return []Node{}, NewGorError(tokens[idx+1], "expected ';'")
+					}
+					nodes = append(nodes, JumptoNode{tokens[idx+1]})
+					idx += 3
+					continue
+				}
+				return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected identifier, but found '%s' instead", string(tokens[idx].Lit)))
+			case "func":
+				fn, used, err := p.parseFuncLiteral(tokens[idx:], true)
+				if err != nil {
+					return []Node{}, err
+				}
+				nodes = append(nodes, AssignmentNode{Ident: fn.Name, Value: fn})
+				idx += used
+			case "con":
+				conTok := tokens[idx]
+				if !checkTokenType(tokens, idx+1, IDENT) {
+					return []Node{}, NewGorError(conTok, "expected container name")
+				} else if !checkTokenType(tokens, idx+2, LBRACE) {
+					return []Node{}, NewGorError(tokens[idx+1], "expected '{'")
+				}
+				name := tokens[idx+1]
+				idx += 3
+
+				fieldToks, ok := collectUntilToken(tokens[idx:], RBRACE, NULLTOKEN)
+				if !ok {
+					return []Node{}, NewGorError(tokens[idx-1], "expected '}'")
+				}
+				fields, err := parseContainerFields(fieldToks)
+				if err != nil {
+					return []Node{}, err
+				}
+				nodes = append(nodes, ContainerDeclNode{Name: name, Fields: fields})
+				idx += len(fieldToks) + 1
+			case "return":
+				retTok := tokens[idx]
+				idx++
+				exprToks, ok := p.collectUntilStatementEnd(tokens[idx:])
+				if !ok {
+					return []Node{}, NewGorError(retTok, "expected ';'")
+				}
+
+				if onlyNewlines(exprToks) {
+					nodes = append(nodes, ReturnNode{Tok: retTok})
+				} else {
+					gen, err := p.generateExpressionNodeFromTokens(retTok, exprToks)
+					if err != nil {
+						return []Node{}, err
+					}
+					nodes = append(nodes, ReturnNode{Tok: retTok, Value: gen})
+				}
+				idx += len(exprToks) + 1
+			case "use":
+				if checkTokenType(tokens, idx+1, STRING) {
+					nodes = append(nodes, ModuleImportNode{tokens[idx+1]})
+					idx += 2
+					if checkTokenType(tokens, idx, SEMICOLON) {
+						idx++
+					}
+					continue
+				}
+				return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("expected string, but found '%s' instead", string(tokens[idx].Lit)))
+			default:
+				return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("unknown keyword '%s'", tokens[idx].Lit))
+			}
+		} else {
+			return []Node{}, NewGorError(tokens[idx], fmt.Sprintf("unexpected '%s'", tokens[idx].Lit))
+		}
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,101 @@
+package gor
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// something outside of the interpreter that a function needs access to, they can be combined with '|'
+type Capability uint
+
+const (
+	CapStdin Capability = 1 << iota
+	CapFiles
+	CapNetwork
+	CapEnv
+
+	AllCapabilities = CapStdin | CapFiles | CapNetwork | CapEnv
+)
+
+func (c Capability) String() string {
+	var names []string
+	for _, named := range []struct {
+		c    Capability
+		name string
+	}{{CapStdin, "stdin"}, {CapFiles, "files"}, {CapNetwork, "the network"}, {CapEnv, "environment variables"}} {
+		if c&named.c != 0 {
+			names = append(names, named.name)
+		}
+	}
+	if len(names) == 0 {
+		return "nothing"
+	}
+	return strings.Join(names, " and ")
+}
+
+// what the scripts an interpreter runs are allowed to do
+type Policy struct {
+	// the capabilities that functions can use, calling a function which needs anything else is an error
+	Allow Capability
+	// the only directories modules can be imported from, they're also searched for modules that aren't next to the file using them.
+	// nil means modules can be imported from anywhere, while an empty slice means they can't be imported at all
+	ModuleDirs []string
+}
+
+// the policy interpreters start with, which trusts scripts with everything
+func TrustedPolicy() Policy {
+	return Policy{Allow: AllCapabilities}
+}
+
+// sets what the scripts the interpreter runs are allowed to do, the modules they use get the same policy
+func (in *Interpreter) SetPolicy(p Policy) {
+	p.ModuleDirs = slices.Clone(p.ModuleDirs)
+	*in.policy = p
+}
+
+// the error for calling a function that needs capabilities the policy doesn't allow, or nil if it's allowed
+func (p Policy) checkCall(fn *Function, tok Token) error {
+	if missing := fn.Needs &^ p.Allow; missing != 0 {
+		return &GorError{Tok: tok, Msg: fmt.Sprintf("%s: function '%s' needs access to %s, which this script isn't allowed", ErrPermissionDenied, fn.Name, missing), Err: ErrPermissionDenied}
+	}
+	return nil
+}
+
+// whether the module at the path is inside one of the directories modules can be imported from
+func (p Policy) allowsModule(modpath string) bool {
+	if p.ModuleDirs == nil {
+		return true
+	}
+
+	file, err := resolvePath(modpath)
+	if err != nil {
+		return false
+	}
+	for _, dir := range p.ModuleDirs {
+		dir, err := resolvePath(dir)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, file); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// the absolute path with symlinks followed, so a link can't be used to get out of a module directory
+func resolvePath(p string) (string, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved, nil
+	}
+	return abs, nil
+}
@@ -0,0 +1,102 @@
+package gor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectDenied(t *testing.T, err error) {
+	t.Helper()
+	expectError(t, err, ErrPermissionDenied)
+	if err != nil && !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expected the error to say the permission was denied, but got %s", err)
+	}
+}
+
+func TestDeniedBuiltins(t *testing.T) {
+	scripts := []string{
+		`getStr("> ");`,
+		`getEnv("HOME");`,
+		`readFile("policy_test.go");`,
+		// calling it through another name doesn't get around the policy
+		`g <- getStr; g("> ");`,
+		`f <- func(read) { return read("policy_test.go"); }; f(readFile);`,
+	}
+
+	for _, src := range scripts {
+		in, _ := testInterpreter(strings.NewReader("input\n"))
+		in.AddSystemFuncs()
+		in.SetPolicy(Policy{})
+		expectDenied(t, in.Run(src, "test.gor"))
+	}
+}
+
+func TestSystemFuncsAreOptIn(t *testing.T) {
+	in, _ := testInterpreter(strings.NewReader(""))
+	for _, src := range []string{`getEnv("HOME");`, `readFile("policy_test.go");`} {
+		if err := in.Run(src, "test.gor"); err == nil || !strings.Contains(err.Error(), "unknown function") {
+			t.Errorf("running %q: expected an unknown function error, but got %v", src, err)
+		}
+	}
+
+	in.AddSystemFuncs()
+	in.SetPolicy(Policy{Allow: CapEnv})
+	t.Setenv("GOR_TEST_VAR", "hello")
+	if err := in.Run(`puts(getEnv("GOR_TEST_VAR"));`, "test.gor"); err != nil {
+		t.Errorf("expected no error, but got %s", err)
+	}
+	expectDenied(t, in.Run(`readFile("policy_test.go");`, "test.gor"))
+}
+
+func TestModuleDirs(t *testing.T) {
+	root := t.TempDir()
+	mods := filepath.Join(root, "mods")
+	if err := os.Mkdir(mods, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(mods, "allowed.gor"), "x <- 1;")
+	writeFile(t, filepath.Join(root, "outside.gor"), "x <- 2;")
+	main := filepath.Join(root, "main.gor")
+
+	in, out := testInterpreter(strings.NewReader(""))
+	in.SetPolicy(Policy{ModuleDirs: []string{mods}})
+
+	if err := in.Run("use \"allowed\"\nputs(x);", main); err != nil {
+		t.Errorf("expected no error, but got %s", err)
+	} else if got := out.String(); got != "1\n" {
+		t.Errorf("expected output %q, but got %q", "1\n", got)
+	}
+
+	// outside.gor is next to main.gor, but that isn't one of the module directories
+	expectDenied(t, in.Run("use \"outside\"", main))
+	expectDenied(t, in.Run("use \"../outside\"", filepath.Join(mods, "main.gor")))
+
+	in.SetPolicy(Policy{ModuleDirs: []string{}})
+	expectDenied(t, in.Run("use \"allowed\"", filepath.Join(mods, "main.gor")))
+}
+
+func TestModuleSymlinkEscape(t *testing.T) {
+	root := t.TempDir()
+	mods := filepath.Join(root, "mods")
+	if err := os.Mkdir(mods, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(root, "secret.gor")
+	writeFile(t, secret, "x <- 2;")
+	if err := os.Symlink(secret, filepath.Join(mods, "link.gor")); err != nil {
+		t.Skipf("can't make symlinks here: %s", err)
+	}
+
+	in, _ := testInterpreter(strings.NewReader(""))
+	in.SetPolicy(Policy{ModuleDirs: []string{mods}})
+	expectDenied(t, in.Run("use \"link\"", filepath.Join(mods, "main.gor")))
+}
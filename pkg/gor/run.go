@@ -0,0 +1,83 @@
+// Package gor is the Gor language: a lexer, parser and interpreter for a dynamically-typed, R-inspired language.
+//
+// The simplest way to run Gor code is RunGor, while Lex, Parse and Interpret can be used to run each stage on its own.
+package gor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+)
+
+// the variables and functions a Gor file defined, which is what other files get when they 'use' it
+type ModuleImport struct {
+	vars, funcs map[string]Value
+}
+
+// the global variables of the module
+func (m ModuleImport) Vars() map[string]Value {
+	return m.vars
+}
+
+// the builtins and containers of the module
+func (m ModuleImport) Funcs() map[string]Value {
+	return m.funcs
+}
+
+// turns Gor code into tokens
+func Lex(text string) ([]Token, error) {
+	return NewLexer(text).Lex()
+}
+
+// what gets printed to stdout while Gor code runs, which is mostly useful for debugging Gor itself
+type RunOptions struct {
+	PrintTokens bool
+	PrintNodes  bool
+	// prints the global variables once the code is done
+	PrintVars bool
+	// prints the variables after every statement, which overrides PrintVars
+	PrintVarsEachCycle bool
+}
+
+// lexes, parses and interprets Gor code with a new interpreter, file is used in errors and to find modules that the code uses
+func RunGor(text, file string, opts RunOptions) (ModuleImport, error) {
+	return NewInterpreter().RunGor(context.Background(), text, file, opts)
+}
+
+// like RunContext, but returns what the code defined and can print debugging output while running
+func (in *Interpreter) RunGor(ctx context.Context, text, file string, opts RunOptions) (ModuleImport, error) {
+	tokens, lexerErr := NewLexer(text).LexContext(ctx)
+	if lexerErr != nil {
+		return ModuleImport{}, lexerErr
+	} else if opts.PrintTokens {
+		fmt.Fprintln(in.stdout, tokens)
+	}
+
+	nodes, parseErr := ParseContext(ctx, tokens)
+	if parseErr != nil {
+		return ModuleImport{}, parseErr
+	} else if opts.PrintNodes {
+		fmt.Fprintln(in.stdout, nodes)
+	}
+
+	return in.Interpret(ctx, nodes, file, opts)
+}
+
+func readFile(fileName string) (string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	content := ""
+	for scanner.Scan() {
+		content += scanner.Text() + "\n"
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return content, nil
+}
@@ -0,0 +1,986 @@
+package gor
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// a value in Gor
+type Value interface {
+	// the name of the value's type as it's shown to Gor code
+	TypeName() string
+	String() string
+	// whether the value counts as true when used as a condition
+	Truthy() (bool, error)
+	Equals(other Value) bool
+}
+
+// values that can be used with the arithmetic operators
+type arithmetic interface {
+	Arithmetic(op tokType, right Value) (Value, error)
+}
+
+// values that can be used with '<' and '>'
+type Ordered interface {
+	// returns -1 if the value is less than right, 1 if it's greater, and 0 if they're equal
+	Compare(right Value) (int, error)
+}
+
+// values that can be used with unary '-'
+type Negatable interface {
+	Negate() (Value, error)
+}
+
+// returned by Arithmetic and Compare when the operator can't be used with the given values
+var ErrUnsupportedOperation = errors.New("unsupported operation")
+
+var ErrDivisionByZero = errors.New("division by zero")
+
+var ErrIntegerOverflow = errors.New("integer overflow, use bignum() for ints this big")
+
+func notLogicalError(v Value) error {
+	return fmt.Errorf("a value of type '%s' cannot be used as a condition", v.TypeName())
+}
+
+type Int int
+
+func (i Int) TypeName() string {
+	return "int"
+}
+
+func (i Int) String() string {
+	return fmt.Sprint(int(i))
+}
+
+func (i Int) Truthy() (bool, error) {
+	return i != 0, nil
+}
+
+func (i Int) Equals(other Value) bool {
+	switch o := other.(type) {
+	case Int:
+		return i == o
+	case Float:
+		return Float(i) == o
+	case BigInt:
+		return o.Equals(i)
+	}
+	return false
+}
+
+func (i Int) Negate() (Value, error) {
+	if i == math.MinInt {
+		return nil, ErrIntegerOverflow
+	}
+	return -i, nil
+}
+
+func (i Int) big() BigInt {
+	return BigInt{Val: big.NewInt(int64(i))}
+}
+
+// ints are promoted to floats or bignums when used with one
+func (i Int) Arithmetic(op tokType, right Value) (Value, error) {
+	switch r := right.(type) {
+	case Float:
+		return Float(i).Arithmetic(op, r)
+	case BigInt:
+		return i.big().Arithmetic(op, r)
+	case Int:
+		return i.intArithmetic(op, r)
+	}
+	return nil, ErrUnsupportedOperation
+}
+
+// arithmetic between two ints, which errors instead of wrapping around when the result doesn't fit
+func (i Int) intArithmetic(op tokType, r Int) (Value, error) {
+	var res Int
+	var overflowed bool
+
+	switch op {
+	case PLUS:
+		res = i + r
+		overflowed = (i > 0 && r > 0 && res < 0) || (i < 0 && r < 0 && res >= 0)
+	case HYPHEN:
+		res = i - r
+		overflowed = (i >= 0 && r < 0 && res < 0) || (i < 0 && r > 0 && res >= 0)
+	case ASTERISK:
+		res = i * r
+		overflowed = i != 0 && (res/i != r || (i == -1 && r == math.MinInt))
+	case FORWARD_SLASH:
+		if r == 0 {
+			return nil, ErrDivisionByZero
+		}
+		res = i / r
+		overflowed = i == math.MinInt && r == -1
+	case PERCENT_SIGN:
+		if r == 0 {
+			return nil, ErrDivisionByZero
+		}
+		res = i % r
+	default:
+		return nil, ErrUnsupportedOperation
+	}
+
+	if overflowed {
+		return nil, ErrIntegerOverflow
+	}
+	return res, nil
+}
+
+func (i Int) Compare(right Value) (int, error) {
+	switch r := right.(type) {
+	case Int:
+		return cmp.Compare(i, r), nil
+	case Float:
+		return cmp.Compare(Float(i), r), nil
+	case BigInt:
+		return i.big().Compare(r)
+	}
+	return 0, ErrUnsupportedOperation
+}
+
+type Float float64
+
+func (f Float) TypeName() string {
+	return "float"
+}
+
+// floats are shown with up to 7 significant digits, which is what R does by default, but big whole numbers are written out in full
+func (f Float) String() string {
+	if abs := math.Abs(float64(f)); abs >= 1e7 && abs < 1e15 && f == Float(math.Trunc(float64(f))) {
+		return strconv.FormatFloat(float64(f), 'f', -1, 64)
+	}
+	return strconv.FormatFloat(float64(f), 'g', 7, 64)
+}
+
+func (f Float) Truthy() (bool, error) {
+	return f != 0, nil
+}
+
+func (f Float) Equals(other Value) bool {
+	switch o := other.(type) {
+	case Float:
+		return f == o
+	case Int, BigInt:
+		c, err := f.Compare(o)
+		return err == nil && c == 0 && !math.IsNaN(float64(f))
+	}
+	return false
+}
+
+func (f Float) Negate() (Value, error) {
+	return -f, nil
+}
+
+func (f Float) Arithmetic(op tokType, right Value) (Value, error) {
+	var r Float
+	switch v := right.(type) {
+	case Float:
+		r = v
+	case Int:
+		r = Float(v)
+	case BigInt:
+		r = v.float()
+	default:
+		return nil, ErrUnsupportedOperation
+	}
+
+	switch op {
+	case PLUS:
+		return f + r, nil
+	case HYPHEN:
+		return f - r, nil
+	case ASTERISK:
+		return f * r, nil
+	case FORWARD_SLASH:
+		return f / r, nil
+	case PERCENT_SIGN:
+		return Float(math.Mod(float64(f), float64(r))), nil
+	}
+	return nil, ErrUnsupportedOperation
+}
+
+func (f Float) Compare(right Value) (int, error) {
+	switch r := right.(type) {
+	case Float:
+		return cmp.Compare(f, r), nil
+	case Int:
+		return cmp.Compare(f, Float(r)), nil
+	case BigInt:
+		c, err := r.Compare(f)
+		return -c, err
+	}
+	return 0, ErrUnsupportedOperation
+}
+
+// an int of any size, made with bignum(), which stays a bignum through arithmetic with ints
+type BigInt struct {
+	Val *big.Int
+}
+
+func (b BigInt) TypeName() string {
+	return "bignum"
+}
+
+func (b BigInt) String() string {
+	return b.Val.String()
+}
+
+func (b BigInt) Truthy() (bool, error) {
+	return b.Val.Sign() != 0, nil
+}
+
+func (b BigInt) Equals(other Value) bool {
+	switch o := other.(type) {
+	case BigInt:
+		return b.Val.Cmp(o.Val) == 0
+	case Int:
+		return b.Val.Cmp(big.NewInt(int64(o))) == 0
+	case Float:
+		return o.Equals(b)
+	}
+	return false
+}
+
+func (b BigInt) Negate() (Value, error) {
+	return BigInt{Val: new(big.Int).Neg(b.Val)}, nil
+}
+
+func (b BigInt) float() Float {
+	f, _ := new(big.Float).SetInt(b.Val).Float64()
+	return Float(f)
+}
+
+func (b BigInt) Arithmetic(op tokType, right Value) (Value, error) {
+	var r *big.Int
+	switch v := right.(type) {
+	case BigInt:
+		r = v.Val
+	case Int:
+		r = big.NewInt(int64(v))
+	case Float:
+		return b.float().Arithmetic(op, v)
+	default:
+		return nil, ErrUnsupportedOperation
+	}
+
+	res := new(big.Int)
+	switch op {
+	case PLUS:
+		res.Add(b.Val, r)
+	case HYPHEN:
+		res.Sub(b.Val, r)
+	case ASTERISK:
+		res.Mul(b.Val, r)
+	case FORWARD_SLASH:
+		if r.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		res.Quo(b.Val, r)
+	case PERCENT_SIGN:
+		if r.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		res.Rem(b.Val, r)
+	default:
+		return nil, ErrUnsupportedOperation
+	}
+	return BigInt{Val: res}, nil
+}
+
+func (b BigInt) Compare(right Value) (int, error) {
+	switch r := right.(type) {
+	case BigInt:
+		return b.Val.Cmp(r.Val), nil
+	case Int:
+		return b.Val.Cmp(big.NewInt(int64(r))), nil
+	case Float:
+		if math.IsNaN(float64(r)) {
+			return 0, ErrUnsupportedOperation
+		}
+		return new(big.Float).SetInt(b.Val).Cmp(big.NewFloat(float64(r))), nil
+	}
+	return 0, ErrUnsupportedOperation
+}
+
+// the bignum() builtin, which turns an int, a whole float or a string of digits into a bignum
+// the base to parse the digits of an int in, 0 lets the '0x' or '0b' prefix decide.
+// other numbers are always decimal, so a leading 0 doesn't make them octal
+func intBase(digits string) int {
+	digits = strings.TrimLeft(digits, "+-")
+	if len(digits) > 1 && digits[0] == '0' && strings.ContainsRune("xXbB", rune(digits[1])) {
+		return 0
+	}
+	return 10
+}
+
+func ToBigInt(v Value) (Value, error) {
+	switch n := v.(type) {
+	case BigInt:
+		return n, nil
+	case Int:
+		return n.big(), nil
+	case Float:
+		if math.IsInf(float64(n), 0) || math.IsNaN(float64(n)) || n != Float(math.Trunc(float64(n))) {
+			return nil, fmt.Errorf("cannot turn the float %s into a bignum as it isn't a whole number", n)
+		}
+		res, _ := big.NewFloat(float64(n)).Int(nil)
+		return BigInt{Val: res}, nil
+	case String:
+		digits := strings.ReplaceAll(string(n), "_", "")
+		res, ok := new(big.Int).SetString(digits, intBase(digits))
+		if !ok {
+			return nil, fmt.Errorf("cannot turn the string \"%s\" into a bignum", n)
+		}
+		return BigInt{Val: res}, nil
+	}
+	return nil, fmt.Errorf("cannot turn a value of type '%s' into a bignum", v.TypeName())
+}
+
+type String string
+
+func (s String) TypeName() string {
+	return "string"
+}
+
+func (s String) String() string {
+	return string(s)
+}
+
+func (s String) Truthy() (bool, error) {
+	return false, notLogicalError(s)
+}
+
+func (s String) Equals(other Value) bool {
+	o, ok := other.(String)
+	return ok && s == o
+}
+
+// strings are compared lexicographically by their bytes
+func (s String) Compare(right Value) (int, error) {
+	r, ok := right.(String)
+	if !ok {
+		return 0, ErrUnsupportedOperation
+	}
+	return strings.Compare(string(s), string(r)), nil
+}
+
+// the longest a string can get, which is the same as R's limit
+const maxStringLength = math.MaxInt32
+
+func (s String) Arithmetic(op tokType, right Value) (Value, error) {
+	if r, ok := right.(String); ok && op == PLUS {
+		return s + r, nil
+	} else if r, ok := right.(Int); ok && op == ASTERISK {
+		if r < 0 {
+			return nil, fmt.Errorf("cannot repeat a string %d times", r)
+		} else if len(s) > 0 && int(r) > maxStringLength/len(s) {
+			return nil, fmt.Errorf("repeating a string %d times would make it longer than the limit of %d bytes", r, maxStringLength)
+		}
+		return String(strings.Repeat(string(s), int(r))), nil
+	}
+	return nil, ErrUnsupportedOperation
+}
+
+type Bool bool
+
+func (b Bool) TypeName() string {
+	return "bool"
+}
+
+func (b Bool) String() string {
+	return fmt.Sprint(bool(b))
+}
+
+func (b Bool) Truthy() (bool, error) {
+	return bool(b), nil
+}
+
+func (b Bool) Equals(other Value) bool {
+	o, ok := other.(Bool)
+	return ok && b == o
+}
+
+// the value of functions that don't return anything
+type Null struct{}
+
+func (n Null) TypeName() string {
+	return "null"
+}
+
+func (n Null) String() string {
+	return "NULL"
+}
+
+func (n Null) Truthy() (bool, error) {
+	return false, notLogicalError(n)
+}
+
+func (n Null) Equals(other Value) bool {
+	_, ok := other.(Null)
+	return ok
+}
+
+type Vector struct {
+	Elems []Value
+}
+
+func (v Vector) TypeName() string {
+	return "vector"
+}
+
+// vectors are shown like R shows them, e.g. '[1] 1 2 3'
+func (v Vector) String() string {
+	if len(v.Elems) == 0 {
+		return "vector(0)"
+	}
+
+	var elems []string
+	for _, e := range v.Elems {
+		if s, ok := e.(String); ok {
+			elems = append(elems, strconv.Quote(string(s)))
+		} else {
+			elems = append(elems, e.String())
+		}
+	}
+	return "[1] " + strings.Join(elems, " ")
+}
+
+func (v Vector) Truthy() (bool, error) {
+	if len(v.Elems) != 1 {
+		return false, fmt.Errorf("a vector of length %d cannot be used as a condition", len(v.Elems))
+	}
+	return v.Elems[0].Truthy()
+}
+
+func (v Vector) Equals(other Value) bool {
+	o, ok := other.(Vector)
+	if !ok || len(v.Elems) != len(o.Elems) {
+		return false
+	}
+
+	for i, e := range v.Elems {
+		if !e.Equals(o.Elems[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// applies the operator to each pair of elements, recycling the shorter vector like R does
+func (v Vector) Arithmetic(op tokType, right Value) (Value, error) {
+	r, ok := right.(Vector)
+	if !ok {
+		r = Vector{Elems: []Value{right}}
+	}
+	if len(v.Elems) == 0 || len(r.Elems) == 0 {
+		return Vector{}, nil
+	}
+
+	out := make([]Value, max(len(v.Elems), len(r.Elems)))
+	for i := range out {
+		left, ok := v.Elems[i%len(v.Elems)].(arithmetic)
+		if !ok {
+			return nil, ErrUnsupportedOperation
+		}
+
+		res, err := left.Arithmetic(op, r.Elems[i%len(r.Elems)])
+		if err != nil {
+			return nil, err
+		}
+		out[i] = res
+	}
+	return Vector{Elems: out}, nil
+}
+
+func (v Vector) Negate() (Value, error) {
+	out := make([]Value, len(v.Elems))
+	for i, e := range v.Elems {
+		n, ok := e.(Negatable)
+		if !ok {
+			return nil, ErrUnsupportedOperation
+		}
+
+		res, err := n.Negate()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = res
+	}
+	return Vector{Elems: out}, nil
+}
+
+// compares the values with one of the comparison operators, vectors are compared element by element and give a vector of bools
+func CompareValues(op tokType, left, right Value) (Value, error) {
+	l, leftIsVec := left.(Vector)
+	r, rightIsVec := right.(Vector)
+	if !leftIsVec && !rightIsVec {
+		return compareScalars(op, left, right)
+	}
+
+	if !leftIsVec {
+		l = Vector{Elems: []Value{left}}
+	} else if !rightIsVec {
+		r = Vector{Elems: []Value{right}}
+	}
+	if len(l.Elems) == 0 || len(r.Elems) == 0 {
+		return Vector{}, nil
+	}
+
+	out := make([]Value, max(len(l.Elems), len(r.Elems)))
+	for i := range out {
+		res, err := compareScalars(op, l.Elems[i%len(l.Elems)], r.Elems[i%len(r.Elems)])
+		if err != nil {
+			return nil, err
+		}
+		out[i] = res
+	}
+	return Vector{Elems: out}, nil
+}
+
+func compareScalars(op tokType, left, right Value) (Bool, error) {
+	switch op {
+	case EQUALS:
+		return Bool(left.Equals(right)), nil
+	case NOT_EQUALS:
+		return Bool(!left.Equals(right)), nil
+	}
+
+	ordered, ok := left.(Ordered)
+	if !ok {
+		return false, ErrUnsupportedOperation
+	}
+	c, err := ordered.Compare(right)
+	if err != nil {
+		return false, err
+	}
+
+	switch op {
+	case LESSER_THAN:
+		return c < 0, nil
+	case GREATER_THAN:
+		return c > 0, nil
+	case LESSER_EQUALS:
+		return c <= 0, nil
+	case GREATER_EQUALS:
+		return c >= 0, nil
+	}
+	return false, ErrUnsupportedOperation
+}
+
+// indexes the vector like R does, indexes start at 1, negative indexes leave out elements, and bools pick which elements to keep
+func (v Vector) Index(index Value) (Value, error) {
+	switch idx := index.(type) {
+	case Int:
+		if idx > 0 {
+			if int(idx) > len(v.Elems) {
+				return nil, fmt.Errorf("index %d is out of range for a vector of length %d", idx, len(v.Elems))
+			}
+			return v.Elems[idx-1], nil
+		}
+		return v.selectIndexes([]Value{idx})
+	case Bool:
+		return v.selectIndexes([]Value{idx})
+	case Vector:
+		return v.selectIndexes(idx.Elems)
+	}
+	return nil, fmt.Errorf("cannot index a vector with a value of type '%s'", index.TypeName())
+}
+
+// the vector without the elements the index selects
+func (v Vector) Remove(index Value) (Value, error) {
+	positions := make([]Value, len(v.Elems))
+	for i := range v.Elems {
+		positions[i] = Int(i + 1)
+	}
+
+	selected, err := Vector{Elems: positions}.Index(index)
+	if err != nil {
+		return nil, err
+	}
+	removed := make(map[Int]bool)
+	if vec, ok := selected.(Vector); ok {
+		for _, p := range vec.Elems {
+			removed[p.(Int)] = true
+		}
+	} else {
+		removed[selected.(Int)] = true
+	}
+
+	out := []Value{}
+	for i, e := range v.Elems {
+		if !removed[Int(i+1)] {
+			out = append(out, e)
+		}
+	}
+	return Vector{Elems: out}, nil
+}
+
+func (v Vector) selectIndexes(indexes []Value) (Value, error) {
+	if len(indexes) == 0 {
+		return Vector{}, nil
+	}
+
+	if _, isLogical := indexes[0].(Bool); isLogical {
+		if len(indexes) > len(v.Elems) {
+			return nil, fmt.Errorf("cannot index a vector of length %d with %d bools", len(v.Elems), len(indexes))
+		}
+
+		var out []Value
+		for i, e := range v.Elems {
+			keep, ok := indexes[i%len(indexes)].(Bool)
+			if !ok {
+				return nil, fmt.Errorf("cannot mix bools with values of type '%s' in an index", indexes[i%len(indexes)].TypeName())
+			} else if keep {
+				out = append(out, e)
+			}
+		}
+		return Vector{Elems: out}, nil
+	}
+
+	var positive, negative []int
+	for _, index := range indexes {
+		i, ok := index.(Int)
+		if !ok {
+			return nil, fmt.Errorf("cannot index a vector with a value of type '%s'", index.TypeName())
+		} else if i == 0 {
+			return nil, errors.New("vectors are indexed from 1")
+		} else if int(max(i, -i)) > len(v.Elems) {
+			return nil, fmt.Errorf("index %d is out of range for a vector of length %d", i, len(v.Elems))
+		} else if i > 0 {
+			positive = append(positive, int(i))
+		} else {
+			negative = append(negative, int(-i))
+		}
+	}
+
+	if len(positive) > 0 && len(negative) > 0 {
+		return nil, errors.New("cannot mix positive and negative indexes")
+	}
+
+	var out []Value
+	if len(positive) > 0 {
+		for _, i := range positive {
+			out = append(out, v.Elems[i-1])
+		}
+	} else {
+		for i, e := range v.Elems {
+			if !slices.Contains(negative, i+1) {
+				out = append(out, e)
+			}
+		}
+	}
+	return Vector{Elems: out}, nil
+}
+
+// how far along a type is in R's coercion order, values are converted to the highest type in a vector
+func coercionRank(v Value) int {
+	switch v.(type) {
+	case Bool:
+		return 0
+	case Int:
+		return 1
+	case BigInt:
+		return 2
+	case Float:
+		return 3
+	case String:
+		return 4
+	}
+	return -1
+}
+
+func coerce(v Value, rank int) Value {
+	if b, ok := v.(Bool); ok && rank >= 1 && rank <= 3 {
+		if b {
+			v = Int(1)
+		} else {
+			v = Int(0)
+		}
+	}
+
+	switch rank {
+	case 2:
+		if i, ok := v.(Int); ok {
+			return i.big()
+		}
+	case 3:
+		if i, ok := v.(Int); ok {
+			return Float(i)
+		} else if b, ok := v.(BigInt); ok {
+			return b.float()
+		}
+	case 4:
+		return String(v.String())
+	}
+	return v
+}
+
+// creates a vector out of the given values, vectors are flattened and every element is converted to the same type
+func Combine(values ...Value) (Value, error) {
+	var elems []Value
+	for _, v := range values {
+		if vec, ok := v.(Vector); ok {
+			elems = append(elems, vec.Elems...)
+		} else if _, ok := v.(Null); !ok {
+			elems = append(elems, v)
+		}
+	}
+
+	if len(elems) == 0 {
+		return Null{}, nil
+	}
+
+	rank := 0
+	for _, e := range elems {
+		r := coercionRank(e)
+		if r == -1 {
+			return nil, fmt.Errorf("cannot put a value of type '%s' into a vector", e.TypeName())
+		}
+		rank = max(rank, r)
+	}
+
+	for i, e := range elems {
+		elems[i] = coerce(e, rank)
+	}
+	return Vector{Elems: elems}, nil
+}
+
+// the longest a vector can be, which is the same as R's limit for vectors that aren't long vectors
+const maxVectorLength = math.MaxInt32
+
+// the length of a vector going through steps steps past its first element, or an error if it'd be too long
+func vectorLength(steps float64) (int, error) {
+	if math.IsNaN(steps) || steps+1 > maxVectorLength {
+		return 0, fmt.Errorf("the vector would be longer than the limit of %d elements", maxVectorLength)
+	}
+	return int(steps) + 1, nil
+}
+
+// the arguments of the ':' operator, which creates a vector of every int from start to end counting down if end is smaller,
+// and how many elements it would create
+func rangeArgs(start, end Value) (from, to Int, length int, err error) {
+	from, fromOk := start.(Int)
+	to, toOk := end.(Int)
+	if !fromOk || !toOk {
+		return 0, 0, 0, ErrUnsupportedOperation
+	}
+
+	length, err = vectorLength(math.Abs(float64(to) - float64(from)))
+	return from, to, length, err
+}
+
+// the element of from:to at i
+func rangeElem(from, to Int, i int) Value {
+	if to < from {
+		return from - Int(i)
+	}
+	return from + Int(i)
+}
+
+// the numbers seq counts with, and how many it counts.
+// seq counts from 'from' to 'to' in steps of 'by', which is 1 or -1 if it isn't given
+type seqSpec struct {
+	from, by float64
+	length   int
+	allInts  bool
+}
+
+func parseSeq(args ...Value) (seqSpec, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return seqSpec{}, fmt.Errorf("seq expects 2 or 3 arguments, but was given %d", len(args))
+	}
+
+	allInts := true
+	nums := make([]float64, len(args))
+	for i, a := range args {
+		switch n := a.(type) {
+		case Int:
+			nums[i] = float64(n)
+		case Float:
+			if math.IsNaN(float64(n)) || math.IsInf(float64(n), 0) {
+				return seqSpec{}, fmt.Errorf("seq expects finite numbers, but argument %d was %s", i+1, n)
+			}
+			nums[i] = float64(n)
+			allInts = false
+		default:
+			return seqSpec{}, fmt.Errorf("seq expects numbers, but argument %d was of type '%s'", i+1, a.TypeName())
+		}
+	}
+
+	from, to := nums[0], nums[1]
+	by := 1.0
+	if len(nums) == 3 {
+		by = nums[2]
+	} else if to < from {
+		by = -1
+	}
+
+	if by == 0 {
+		return seqSpec{}, errors.New("seq cannot count in steps of 0")
+	} else if (to-from)/by < 0 {
+		return seqSpec{}, errors.New("seq's step goes the wrong direction")
+	}
+
+	length, err := vectorLength((to-from)/by + 1e-9)
+	if err != nil {
+		return seqSpec{}, err
+	}
+	return seqSpec{from: from, by: by, length: length, allInts: allInts}, nil
+}
+
+// the element of the sequence at i
+func (s seqSpec) elem(i int) Value {
+	n := s.from + float64(i)*s.by
+	if s.allInts {
+		return Int(n)
+	}
+	return Float(n)
+}
+
+// a function defined in Gor code, or a builtin if Native is set
+type Function struct {
+	Name    string
+	Params  []Token
+	Body    []Node
+	Closure *Environment
+	// the file the function was written in, 'use' inside of it looks for modules next to it
+	File string
+	// a Go function which is called through reflection, see RegisterFunc for what it can take and return
+	Native any
+	// what the function needs access to, which the interpreter's policy has to allow for it to be called
+	Needs Capability
+}
+
+func (fn *Function) TypeName() string {
+	return "function"
+}
+
+func (fn *Function) String() string {
+	if fn.Native != nil {
+		return fmt.Sprintf("<builtin func %s>", fn.Name)
+	}
+
+	var params []string
+	for _, p := range fn.Params {
+		params = append(params, p.Lit)
+	}
+	return fmt.Sprintf("<func %s(%s)>", fn.Name, strings.Join(params, ", "))
+}
+
+func (fn *Function) Truthy() (bool, error) {
+	return false, notLogicalError(fn)
+}
+
+func (fn *Function) Equals(other Value) bool {
+	o, ok := other.(*Function)
+	return ok && fn == o
+}
+
+// the type created by a 'con' declaration, calling it creates a new container
+type ContainerType struct {
+	Name   string
+	Fields []string
+}
+
+func (ct ContainerType) TypeName() string {
+	return "con"
+}
+
+func (ct ContainerType) String() string {
+	return fmt.Sprintf("<con %s>", ct.Name)
+}
+
+func (ct ContainerType) Truthy() (bool, error) {
+	return false, notLogicalError(ct)
+}
+
+func (ct ContainerType) Equals(other Value) bool {
+	o, ok := other.(ContainerType)
+	return ok && ct.Name == o.Name
+}
+
+func (ct ContainerType) New(identTok Token, args []Value) (*Container, error) {
+	if len(args) != len(ct.Fields) {
+		return nil, NewGorError(identTok, fmt.Sprintf("container '%s' expects %d fields, but was given %d", ct.Name, len(ct.Fields), len(args)))
+	}
+
+	con := &Container{Type: ct, Values: make(map[string]Value)}
+	for i, f := range ct.Fields {
+		con.Values[f] = args[i]
+	}
+	return con, nil
+}
+
+type Container struct {
+	Type   ContainerType
+	Values map[string]Value
+}
+
+func (c *Container) TypeName() string {
+	return c.Type.Name
+}
+
+func (c *Container) String() string {
+	var fields []string
+	for _, f := range c.Type.Fields {
+		if val, ok := c.Values[f]; ok {
+			fields = append(fields, fmt.Sprintf("%s: %s", f, val))
+		}
+	}
+	return fmt.Sprintf("%s{%s}", c.Type.Name, strings.Join(fields, ", "))
+}
+
+func (c *Container) Truthy() (bool, error) {
+	return false, notLogicalError(c)
+}
+
+func (c *Container) Equals(other Value) bool {
+	o, ok := other.(*Container)
+	return ok && c == o
+}
+
+func (c *Container) Get(fieldTok Token) (Value, error) {
+	val, ok := c.Values[fieldTok.Lit]
+	if !ok {
+		return nil, NewGorError(fieldTok, fmt.Sprintf("container '%s' has no field '%s'", c.Type.Name, fieldTok.Lit))
+	}
+	return val, nil
+}
+
+// sets the field at the end of the path, going through each nested container on the way
+func (c *Container) SetPath(fieldPath []Token, value Value) error {
+	if len(fieldPath) == 1 {
+		// deleted fields can be set again, as they're still part of the container's type
+		if !slices.Contains(c.Type.Fields, fieldPath[0].Lit) {
+			return NewGorError(fieldPath[0], fmt.Sprintf("container '%s' has no field '%s'", c.Type.Name, fieldPath[0].Lit))
+		}
+		c.Values[fieldPath[0].Lit] = value
+		return nil
+	}
+
+	inner, err := c.Get(fieldPath[0])
+	if err != nil {
+		return err
+	}
+	innerCon, ok := inner.(*Container)
+	if !ok {
+		return NewGorError(fieldPath[1], fmt.Sprintf("cannot access field '%s' of a value of type '%s'", fieldPath[1].Lit, inner.TypeName()))
+	}
+	return innerCon.SetPath(fieldPath[1:], value)
+}
+
+func (c *Container) Delete(fieldTok Token) error {
+	if _, err := c.Get(fieldTok); err != nil {
+		return err
+	}
+	delete(c.Values, fieldTok.Lit)
+	return nil
+}